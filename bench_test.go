@@ -0,0 +1,109 @@
+package optional
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// sliceOptional is the slice based layout that Optional had before it was a
+// struct, kept to compare the two layouts in benchmarks.
+type sliceOptional[T any] []T
+
+func sliceOf[T any](value T) sliceOptional[T] {
+	return sliceOptional[T]{value}
+}
+
+func (o sliceOptional[T]) Get() (value T, ok bool) {
+	if o != nil {
+		return o[0], true
+	}
+	return
+}
+
+func (o *sliceOptional[T]) UnmarshalJSON(data []byte) error {
+	var v T
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+	*o = sliceOf(v)
+	return nil
+}
+
+var (
+	sinkStruct []Optional[int]
+	sinkSlice  []sliceOptional[int]
+)
+
+func BenchmarkOf(b *testing.B) {
+	b.Run("struct", func(b *testing.B) {
+		b.ReportAllocs()
+		sinkStruct = make([]Optional[int], b.N)
+		for i := 0; i < b.N; i++ {
+			sinkStruct[i] = Of(i)
+		}
+	})
+	b.Run("slice", func(b *testing.B) {
+		b.ReportAllocs()
+		sinkSlice = make([]sliceOptional[int], b.N)
+		for i := 0; i < b.N; i++ {
+			sinkSlice[i] = sliceOf(i)
+		}
+	})
+}
+
+func BenchmarkGet(b *testing.B) {
+	b.Run("struct", func(b *testing.B) {
+		o := Of(1)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, ok := o.Get(); !ok {
+				b.Fatal("not present")
+			}
+		}
+	})
+	b.Run("slice", func(b *testing.B) {
+		o := sliceOf(1)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, ok := o.Get(); !ok {
+				b.Fatal("not present")
+			}
+		}
+	})
+}
+
+const benchmarkRecord = `{"a":1,"b":"b","c":true,"d":2.5}`
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	b.Run("struct", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var r struct {
+				A Optional[int]     `json:"a"`
+				B Optional[string]  `json:"b"`
+				C Optional[bool]    `json:"c"`
+				D Optional[float64] `json:"d"`
+			}
+			if err := json.Unmarshal([]byte(benchmarkRecord), &r); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("slice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var r struct {
+				A sliceOptional[int]     `json:"a"`
+				B sliceOptional[string]  `json:"b"`
+				C sliceOptional[bool]    `json:"c"`
+				D sliceOptional[float64] `json:"d"`
+			}
+			if err := json.Unmarshal([]byte(benchmarkRecord), &r); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,31 @@
+// Command optionalmigrate migrates struct fields that use pointers or the
+// sql.Null types to represent the lack of a value to optional.Optional, and
+// rewrites the uses of those fields that have a direct equivalent.
+//
+// The fields to migrate are listed with the -fields flag, each written as the
+// import path of the package declaring the struct, the struct type, and the
+// field:
+//
+//	optionalmigrate -fields=example.com/app/user.User.Email ./...
+//
+// Without flags, the rewrites are reported along with the uses that cannot be
+// rewritten and must be migrated by hand. To preview the rewrites as a diff
+// without changing any files:
+//
+//	optionalmigrate -fields=example.com/app/user.User.Email -fix -diff ./...
+//
+// To apply the rewrites:
+//
+//	optionalmigrate -fields=example.com/app/user.User.Email -fix ./...
+//
+// Packages that use a field but do not declare it are only rewritten if they
+// are loaded, so run the command on every package that may use the fields.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(Analyzer)
+}
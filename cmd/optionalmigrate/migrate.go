@@ -0,0 +1,766 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const doc = `migrate pointer and sql.Null fields to optional.Optional
+
+The optionalmigrate analyzer rewrites the types of the fields listed in the
+-fields flag to optional.Optional[T], and rewrites the uses of those fields
+that have a direct equivalent:
+
+  - a *T field becomes optional.Optional[T], where:
+    - x != nil and x == nil become x.IsPresent() and !x.IsPresent();
+    - *x guarded by if x != nil becomes a value from x.Get();
+    - assigning &v becomes optional.Of(v), nil becomes optional.Empty[T](),
+      and any other pointer becomes optional.OfPtr(p);
+  - a sql.NullString, sql.NullInt64, or other sql.Null field becomes
+    optional.Optional[T] for the type it holds, where:
+    - x.Valid becomes x.IsPresent();
+    - reading the value, such as x.String, becomes x.ElseZero();
+    - assigning a sql.Null literal becomes optional.Of(v) or
+      optional.Empty[T]().
+
+Every rewrite is a suggested fix. Uses that cannot be rewritten safely, such
+as taking the address of a *T field or writing through it, are reported
+without a fix and must be migrated by hand.`
+
+// Analyzer migrates pointer and sql.Null fields to optional.Optional.
+var Analyzer = &analysis.Analyzer{
+	Name: "optionalmigrate",
+	Doc:  doc,
+	Run:  run,
+}
+
+var fieldsFlag string
+
+func init() {
+	Analyzer.Flags.StringVar(&fieldsFlag, "fields", "", "comma-separated list of fields to migrate, each written as importpath.Type.Field")
+}
+
+const (
+	optionalPath = "4d63.com/optional"
+	sqlPath      = "database/sql"
+)
+
+type fieldKind int
+
+const (
+	pointerField fieldKind = iota
+	sqlNullField
+)
+
+// target is a field being migrated.
+type target struct {
+	name       string // Type.Field, for messages
+	kind       fieldKind
+	elem       types.Type // the type wrapped by the optional
+	valueField string     // the field of a sql.Null type holding the value
+}
+
+// sqlNullValueFields maps the sql.Null types to the field holding their value.
+var sqlNullValueFields = map[string]string{
+	"NullBool":    "Bool",
+	"NullByte":    "Byte",
+	"NullFloat64": "Float64",
+	"NullInt16":   "Int16",
+	"NullInt32":   "Int32",
+	"NullInt64":   "Int64",
+	"NullString":  "String",
+	"NullTime":    "Time",
+	"Null":        "V",
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	targets, err := resolveTargets(pass)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, nil
+	}
+	for _, f := range pass.Files {
+		m, err := newMigration(pass, f, targets)
+		if err != nil {
+			return nil, err
+		}
+		m.run()
+		m.report()
+	}
+	return nil, nil
+}
+
+// resolveTargets returns the fields listed in the -fields flag that are
+// declared in or imported by the package being analyzed.
+func resolveTargets(pass *analysis.Pass) (map[*types.Var]*target, error) {
+	targets := map[*types.Var]*target{}
+	for _, spec := range strings.Split(fieldsFlag, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		path, typeName, fieldName, ok := splitFieldSpec(spec)
+		if !ok {
+			return nil, fmt.Errorf("invalid field %q: want importpath.Type.Field", spec)
+		}
+		pkg := findPackage(pass.Pkg, path)
+		if pkg == nil {
+			continue
+		}
+		local := pkg == pass.Pkg
+		tn, _ := pkg.Scope().Lookup(typeName).(*types.TypeName)
+		if tn == nil {
+			if local {
+				return nil, fmt.Errorf("invalid field %q: type %s not found", spec, typeName)
+			}
+			continue
+		}
+		st, _ := tn.Type().Underlying().(*types.Struct)
+		var field *types.Var
+		if st != nil {
+			for i := 0; i < st.NumFields(); i++ {
+				if st.Field(i).Name() == fieldName {
+					field = st.Field(i)
+				}
+			}
+		}
+		if field == nil {
+			if local {
+				return nil, fmt.Errorf("invalid field %q: field %s not found in %s", spec, fieldName, typeName)
+			}
+			continue
+		}
+		t := newTarget(typeName+"."+fieldName, field.Type())
+		if t == nil {
+			if local {
+				pass.Reportf(field.Pos(), "cannot migrate %s.%s of type %s; only pointer and sql.Null fields can be migrated", typeName, fieldName, field.Type())
+			}
+			continue
+		}
+		targets[field] = t
+	}
+	return targets, nil
+}
+
+// splitFieldSpec splits a field written as importpath.Type.Field.
+func splitFieldSpec(spec string) (path, typeName, fieldName string, ok bool) {
+	i := strings.LastIndex(spec, ".")
+	if i <= 0 {
+		return "", "", "", false
+	}
+	j := strings.LastIndex(spec[:i], ".")
+	if j <= 0 {
+		return "", "", "", false
+	}
+	return spec[:j], spec[j+1 : i], spec[i+1:], spec[i+1:] != "" && spec[j+1:i] != ""
+}
+
+// findPackage returns the package with the path if it is the package or one
+// of its direct imports.
+func findPackage(pkg *types.Package, path string) *types.Package {
+	if pkg.Path() == path {
+		return pkg
+	}
+	for _, imp := range pkg.Imports() {
+		if imp.Path() == path {
+			return imp
+		}
+	}
+	return nil
+}
+
+// newTarget returns a target for a field of the type, or nil if fields of the
+// type cannot be migrated.
+func newTarget(name string, t types.Type) *target {
+	switch t := types.Unalias(t).(type) {
+	case *types.Pointer:
+		return &target{name: name, kind: pointerField, elem: t.Elem()}
+	case *types.Named:
+		obj := t.Origin().Obj()
+		if obj.Pkg() == nil || obj.Pkg().Path() != sqlPath {
+			return nil
+		}
+		valueField, ok := sqlNullValueFields[obj.Name()]
+		if !ok {
+			return nil
+		}
+		st := t.Underlying().(*types.Struct)
+		for i := 0; i < st.NumFields(); i++ {
+			if st.Field(i).Name() == valueField {
+				return &target{name: name, kind: sqlNullField, elem: st.Field(i).Type(), valueField: valueField}
+			}
+		}
+	}
+	return nil
+}
+
+// migration holds the state of migrating the fields used in a single file.
+type migration struct {
+	pass    *analysis.Pass
+	file    *ast.File
+	src     []byte
+	tokFile *token.File
+	targets map[*types.Var]*target
+
+	imports    map[string]string // import path to local name
+	newImports map[string]string // import path to local name, of imports to add
+	consumed   map[ast.Node]bool // nodes already rewritten
+	diags      []analysis.Diagnostic
+}
+
+func newMigration(pass *analysis.Pass, f *ast.File, targets map[*types.Var]*target) (*migration, error) {
+	tokFile := pass.Fset.File(f.Pos())
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return nil, err
+	}
+	m := &migration{
+		pass:       pass,
+		file:       f,
+		src:        src,
+		tokFile:    tokFile,
+		targets:    targets,
+		imports:    map[string]string{},
+		newImports: map[string]string{},
+		consumed:   map[ast.Node]bool{},
+	}
+	for _, spec := range f.Imports {
+		path := strings.Trim(spec.Path.Value, "`\"")
+		if spec.Name != nil {
+			m.imports[path] = spec.Name.Name
+		} else if obj, ok := pass.TypesInfo.Implicits[spec].(*types.PkgName); ok {
+			m.imports[path] = obj.Imported().Name()
+		}
+	}
+	return m, nil
+}
+
+func (m *migration) run() {
+	ast.Inspect(m.file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.StructType:
+			m.fieldDecls(n)
+		case *ast.IfStmt:
+			m.guardedIf(n)
+		}
+		return true
+	})
+
+	var stack []ast.Node
+	ast.Inspect(m.file, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+		if m.consumed[n] {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if t := m.targetOf(n); t != nil {
+				m.use(n, t, stack)
+			}
+		case *ast.KeyValueExpr:
+			if key, ok := n.Key.(*ast.Ident); ok {
+				if v, ok := m.pass.TypesInfo.Uses[key].(*types.Var); ok && m.targets[v] != nil {
+					m.assign(n.Value, m.targets[v])
+				}
+			}
+		}
+		return true
+	})
+}
+
+// targetOf returns the target referred to by the selector, or nil.
+func (m *migration) targetOf(sel *ast.SelectorExpr) *target {
+	s, ok := m.pass.TypesInfo.Selections[sel]
+	if !ok || s.Kind() != types.FieldVal {
+		return nil
+	}
+	v, _ := s.Obj().(*types.Var)
+	return m.targets[v]
+}
+
+// fieldDecls rewrites the types of declarations of targets in the struct.
+func (m *migration) fieldDecls(st *ast.StructType) {
+	for _, field := range st.Fields.List {
+		for _, name := range field.Names {
+			v, _ := m.pass.TypesInfo.Defs[name].(*types.Var)
+			t := m.targets[v]
+			if t == nil {
+				continue
+			}
+			if len(field.Names) > 1 {
+				m.cannot(field, "cannot migrate %s declared with other fields; declare it separately", t.name)
+				continue
+			}
+			var elem string
+			if star, ok := field.Type.(*ast.StarExpr); ok {
+				elem = m.text(star.X)
+			} else {
+				var ok bool
+				elem, ok = m.typeString(t.elem)
+				if !ok {
+					m.cannot(field, "cannot migrate %s because %s is not accessible in this file", t.name, t.elem)
+					continue
+				}
+			}
+			m.rewrite(field.Type, fmt.Sprintf("migrate %s to optional.Optional[%s]", t.name, elem),
+				fmt.Sprintf("%s.Optional[%s]", m.optional(), elem))
+		}
+	}
+}
+
+// guardedIf rewrites an if statement that checks a pointer target is not nil
+// and only reads the value it points to, to one that gets the value from the
+// optional.
+func (m *migration) guardedIf(ifs *ast.IfStmt) {
+	if ifs.Init != nil {
+		return
+	}
+	cond, ok := ifs.Cond.(*ast.BinaryExpr)
+	if !ok || cond.Op != token.NEQ || !m.isNil(cond.Y) {
+		return
+	}
+	sel, ok := cond.X.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	t := m.targetOf(sel)
+	if t == nil || t.kind != pointerField {
+		return
+	}
+	name := lowerFirst(sel.Sel.Name)
+	if name == "ok" || token.IsKeyword(name) || types.Universe.Lookup(name) != nil {
+		name += "Value"
+	}
+
+	expr := types.ExprString(sel)
+	var derefs []*ast.StarExpr
+	safe := true
+	var stack []ast.Node
+	ast.Inspect(ifs.Body, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+		switch n := n.(type) {
+		case *ast.Ident:
+			if n.Name == name || n.Name == "ok" {
+				safe = false
+			}
+		case *ast.SelectorExpr:
+			if types.ExprString(n) != expr || m.targetOf(n) != t {
+				return true
+			}
+			star, ok := parent(stack).(*ast.StarExpr)
+			if !ok || isWrite(stack[:len(stack)-1]) {
+				safe = false
+				return true
+			}
+			derefs = append(derefs, star)
+		}
+		return true
+	})
+	if !safe || len(derefs) == 0 {
+		return
+	}
+
+	edits := []analysis.TextEdit{m.edit(cond, fmt.Sprintf("%s, ok := %s.Get(); ok", name, m.text(sel)))}
+	m.consumed[sel] = true
+	for _, star := range derefs {
+		edits = append(edits, m.edit(star, name))
+		m.consumed[star.X] = true
+	}
+	m.fix(cond, fmt.Sprintf("rewrite nil check of %s to Get", t.name), edits...)
+}
+
+// use rewrites a use of a target that is not part of a guarded if statement.
+// The stack holds the nodes enclosing the use, ending with the use.
+func (m *migration) use(sel *ast.SelectorExpr, t *target, stack []ast.Node) {
+	p := parent(stack)
+	if assign, ok := p.(*ast.AssignStmt); ok && assign.Tok == token.ASSIGN && len(assign.Lhs) == len(assign.Rhs) {
+		for i, lhs := range assign.Lhs {
+			if lhs == sel {
+				m.assign(assign.Rhs[i], t)
+				return
+			}
+		}
+	}
+
+	switch t.kind {
+	case pointerField:
+		if bin, ok := p.(*ast.BinaryExpr); ok && (bin.Op == token.EQL || bin.Op == token.NEQ) {
+			other := bin.Y
+			if other == sel {
+				other = bin.X
+			}
+			if m.isNil(other) {
+				not := ""
+				if bin.Op == token.EQL {
+					not = "!"
+				}
+				m.rewrite(bin, fmt.Sprintf("rewrite nil comparison of %s to IsPresent", t.name),
+					fmt.Sprintf("%s%s.IsPresent()", not, m.text(sel)))
+				return
+			}
+		}
+		if _, ok := p.(*ast.StarExpr); ok {
+			m.cannot(p, "cannot rewrite dereference of %s; migrate it by hand", t.name)
+			return
+		}
+	case sqlNullField:
+		if outer, ok := p.(*ast.SelectorExpr); ok && outer.X == sel {
+			switch outer.Sel.Name {
+			case "Valid", t.valueField:
+				if isWrite(stack[:len(stack)-1]) {
+					m.cannot(outer, "cannot rewrite write to %s.%s; migrate it by hand", t.name, outer.Sel.Name)
+					return
+				}
+				method := "IsPresent"
+				if outer.Sel.Name == t.valueField {
+					method = "ElseZero"
+				}
+				m.rewrite(outer, fmt.Sprintf("rewrite %s.%s to %s", t.name, outer.Sel.Name, method),
+					fmt.Sprintf("%s.%s()", m.text(sel), method))
+				return
+			case "Scan", "Value":
+				// Optional implements sql.Scanner and driver.Valuer.
+				return
+			}
+		}
+		if u, ok := p.(*ast.UnaryExpr); ok && u.Op == token.AND {
+			// Optional implements sql.Scanner, so its address can be
+			// passed to Rows.Scan the same as the sql.Null type's.
+			return
+		}
+	}
+	m.cannot(sel, "cannot rewrite this use of %s; migrate it by hand", t.name)
+}
+
+// assign rewrites a value assigned to a target.
+func (m *migration) assign(value ast.Expr, t *target) {
+	switch t.kind {
+	case pointerField:
+		if u, ok := value.(*ast.UnaryExpr); ok && u.Op == token.AND {
+			m.rewrite(value, fmt.Sprintf("rewrite address assigned to %s to optional.Of", t.name),
+				fmt.Sprintf("%s.Of(%s)", m.optional(), m.text(u.X)))
+			return
+		}
+		if m.isNil(value) {
+			m.empty(value, t)
+			return
+		}
+		m.rewrite(value, fmt.Sprintf("rewrite pointer assigned to %s to optional.OfPtr", t.name),
+			fmt.Sprintf("%s.OfPtr(%s)", m.optional(), m.text(value)))
+	case sqlNullField:
+		lit, ok := value.(*ast.CompositeLit)
+		if !ok {
+			m.cannot(value, "cannot rewrite value assigned to %s; migrate it by hand", t.name)
+			return
+		}
+		var v ast.Expr
+		valid := false
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				m.cannot(value, "cannot rewrite value assigned to %s; migrate it by hand", t.name)
+				return
+			}
+			switch key := types.ExprString(kv.Key); {
+			case key == t.valueField:
+				v = kv.Value
+			case key == "Valid" && types.ExprString(kv.Value) == "true":
+				valid = true
+			case key == "Valid" && types.ExprString(kv.Value) == "false":
+			default:
+				m.cannot(value, "cannot rewrite value assigned to %s; migrate it by hand", t.name)
+				return
+			}
+		}
+		switch {
+		case !valid:
+			m.empty(value, t)
+		case v != nil:
+			m.rewrite(value, fmt.Sprintf("rewrite value assigned to %s to optional.Of", t.name),
+				fmt.Sprintf("%s.Of(%s)", m.optional(), m.text(v)))
+		default:
+			m.cannot(value, "cannot rewrite value assigned to %s; migrate it by hand", t.name)
+		}
+	}
+}
+
+// empty rewrites a value assigned to a target to an empty optional.
+func (m *migration) empty(value ast.Expr, t *target) {
+	elem, ok := m.typeString(t.elem)
+	if !ok {
+		m.cannot(value, "cannot rewrite value assigned to %s because %s is not accessible in this file", t.name, t.elem)
+		return
+	}
+	m.rewrite(value, fmt.Sprintf("rewrite empty value assigned to %s to optional.Empty", t.name),
+		fmt.Sprintf("%s.Empty[%s]()", m.optional(), elem))
+}
+
+// report reports the diagnostics of the migration, adding and removing
+// imports with the first suggested fix.
+func (m *migration) report() {
+	importEdit, ok := m.importEdit()
+	for i := range m.diags {
+		if ok && len(m.diags[i].SuggestedFixes) > 0 {
+			fix := &m.diags[i].SuggestedFixes[0]
+			fix.TextEdits = append(fix.TextEdits, importEdit)
+			ok = false
+		}
+		m.pass.Report(m.diags[i])
+	}
+}
+
+// importEdit returns an edit that adds the imports needed by the rewrites and
+// removes the import of database/sql if it is no longer used, or false if no
+// imports change.
+func (m *migration) importEdit() (analysis.TextEdit, bool) {
+	var specs []string
+	for path, name := range m.newImports {
+		spec := fmt.Sprintf("%q", path)
+		if name != pathBase(path) {
+			spec = name + " " + spec
+		}
+		specs = append(specs, spec)
+	}
+	sort.Strings(specs)
+	unused := m.unusedSQLImport()
+	switch {
+	case unused != nil && len(specs) > 0:
+		// Replace the unused import in place, so that the edit does not
+		// overlap with one that removes it.
+		sep := "\nimport "
+		if m.importDecl(unused).Lparen.IsValid() {
+			sep = "\n\t"
+		}
+		return m.edit(unused, strings.Join(specs, sep)), true
+	case unused != nil:
+		return m.deleteImport(unused), true
+	case len(specs) > 0:
+		return m.addImports(specs), true
+	}
+	return analysis.TextEdit{}, false
+}
+
+// unusedSQLImport returns the import of database/sql if every use of it in
+// the file has been rewritten.
+func (m *migration) unusedSQLImport() *ast.ImportSpec {
+	var spec *ast.ImportSpec
+	for _, s := range m.file.Imports {
+		if strings.Trim(s.Path.Value, "`\"") == sqlPath && (s.Name == nil || s.Name.Name != "_") {
+			spec = s
+		}
+	}
+	if spec == nil {
+		return nil
+	}
+	used := false
+	ast.Inspect(m.file, func(n ast.Node) bool {
+		if used || m.rewritten(n) {
+			return false
+		}
+		if id, ok := n.(*ast.Ident); ok {
+			if pn, ok := m.pass.TypesInfo.Uses[id].(*types.PkgName); ok && pn.Imported().Path() == sqlPath {
+				used = true
+			}
+		}
+		return true
+	})
+	if used {
+		return nil
+	}
+	return spec
+}
+
+// rewritten returns true if the node is within the range of an edit.
+func (m *migration) rewritten(n ast.Node) bool {
+	if n == nil {
+		return false
+	}
+	for _, d := range m.diags {
+		for _, fix := range d.SuggestedFixes {
+			for _, e := range fix.TextEdits {
+				if e.Pos <= n.Pos() && n.End() <= e.End {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// optional returns the local name of the optional package in the file,
+// adding an import if it is not imported.
+func (m *migration) optional() string {
+	if name, ok := m.imports[optionalPath]; ok {
+		return name
+	}
+	m.imports[optionalPath] = "optional"
+	m.newImports[optionalPath] = "optional"
+	return "optional"
+}
+
+// typeString returns the type as written in the file, adding imports for any
+// packages the type refers to that are not imported. It returns false if the
+// type refers to an unexported type of another package.
+func (m *migration) typeString(t types.Type) (string, bool) {
+	ok := true
+	s := types.TypeString(t, func(pkg *types.Package) string {
+		if pkg == m.pass.Pkg {
+			return ""
+		}
+		if name, found := m.imports[pkg.Path()]; found {
+			return name
+		}
+		m.imports[pkg.Path()] = pkg.Name()
+		m.newImports[pkg.Path()] = pkg.Name()
+		return pkg.Name()
+	})
+	if named, isNamed := types.Unalias(t).(*types.Named); isNamed && !named.Obj().Exported() && named.Obj().Pkg() != m.pass.Pkg {
+		ok = false
+	}
+	return s, ok
+}
+
+// addImports returns an edit that adds the import specs to the file.
+func (m *migration) addImports(specs []string) analysis.TextEdit {
+	for _, decl := range m.file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if gen.Lparen.IsValid() {
+			return analysis.TextEdit{Pos: gen.Rparen, End: gen.Rparen, NewText: []byte("\t" + strings.Join(specs, "\n\t") + "\n")}
+		}
+		return analysis.TextEdit{Pos: gen.End(), End: gen.End(), NewText: []byte("\nimport " + strings.Join(specs, "\nimport "))}
+	}
+	return analysis.TextEdit{Pos: m.file.Name.End(), End: m.file.Name.End(), NewText: []byte("\n\nimport " + strings.Join(specs, "\nimport "))}
+}
+
+// importDecl returns the import declaration holding the import spec.
+func (m *migration) importDecl(spec *ast.ImportSpec) *ast.GenDecl {
+	for _, decl := range m.file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		for _, s := range gen.Specs {
+			if s == spec {
+				return gen
+			}
+		}
+	}
+	return nil
+}
+
+// deleteImport returns an edit that deletes the import from the file.
+func (m *migration) deleteImport(spec *ast.ImportSpec) analysis.TextEdit {
+	var node ast.Node = spec
+	if gen := m.importDecl(spec); !gen.Lparen.IsValid() {
+		node = gen
+	}
+	start := m.tokFile.LineStart(m.tokFile.Line(node.Pos()))
+	end := node.End()
+	if line := m.tokFile.Line(end); line < m.tokFile.LineCount() {
+		end = m.tokFile.LineStart(line + 1)
+	}
+	return analysis.TextEdit{Pos: start, End: end}
+}
+
+// rewrite reports a diagnostic with a fix that replaces the node.
+func (m *migration) rewrite(n ast.Node, msg, newText string) {
+	m.fix(n, msg, m.edit(n, newText))
+}
+
+// fix reports a diagnostic at the node with a fix made of the edits.
+func (m *migration) fix(n ast.Node, msg string, edits ...analysis.TextEdit) {
+	m.diags = append(m.diags, analysis.Diagnostic{
+		Pos:     n.Pos(),
+		End:     n.End(),
+		Message: msg,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   msg,
+			TextEdits: edits,
+		}},
+	})
+}
+
+// cannot reports a diagnostic at the node without a fix.
+func (m *migration) cannot(n ast.Node, format string, args ...any) {
+	m.diags = append(m.diags, analysis.Diagnostic{
+		Pos:     n.Pos(),
+		End:     n.End(),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// edit returns an edit that replaces the node.
+func (m *migration) edit(n ast.Node, newText string) analysis.TextEdit {
+	return analysis.TextEdit{Pos: n.Pos(), End: n.End(), NewText: []byte(newText)}
+}
+
+// text returns the source text of the node.
+func (m *migration) text(n ast.Node) string {
+	return string(m.src[m.tokFile.Offset(n.Pos()):m.tokFile.Offset(n.End())])
+}
+
+func (m *migration) isNil(e ast.Expr) bool {
+	tv, ok := m.pass.TypesInfo.Types[e]
+	return ok && tv.IsNil()
+}
+
+func parent(stack []ast.Node) ast.Node {
+	if len(stack) < 2 {
+		return nil
+	}
+	return stack[len(stack)-2]
+}
+
+// isWrite returns true if the expression at the top of the stack is written
+// to or has its address taken.
+func isWrite(stack []ast.Node) bool {
+	e := stack[len(stack)-1]
+	switch p := parent(stack).(type) {
+	case *ast.AssignStmt:
+		for _, lhs := range p.Lhs {
+			if lhs == e {
+				return true
+			}
+		}
+	case *ast.IncDecStmt:
+		return p.X == e
+	case *ast.UnaryExpr:
+		return p.Op == token.AND
+	case *ast.RangeStmt:
+		return p.Key == e || p.Value == e
+	}
+	return false
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func pathBase(path string) string {
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
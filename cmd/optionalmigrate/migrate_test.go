@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func Test(t *testing.T) {
+	err := Analyzer.Flags.Set("fields", "a.User.Email,a.User.Age,a.User.Name,a.User.Score,c.Account.Plan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "a", "b", "c")
+}
+
+func TestSplitFieldSpec(t *testing.T) {
+	cases := []struct {
+		spec                      string
+		path, typeName, fieldName string
+		ok                        bool
+	}{
+		{"example.com/app/user.User.Email", "example.com/app/user", "User", "Email", true},
+		{"a.User.Email", "a", "User", "Email", true},
+		{"User.Email", "", "", "", false},
+		{"a.User.", "", "", "", false},
+		{"a..Email", "", "", "", false},
+	}
+	for _, c := range cases {
+		t.Run(c.spec, func(t *testing.T) {
+			path, typeName, fieldName, ok := splitFieldSpec(c.spec)
+			if ok != c.ok || ok && (path != c.path || typeName != c.typeName || fieldName != c.fieldName) {
+				t.Errorf("got %q %q %q %v, want %q %q %q %v", path, typeName, fieldName, ok, c.path, c.typeName, c.fieldName, c.ok)
+			}
+		})
+	}
+}
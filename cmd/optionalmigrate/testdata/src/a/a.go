@@ -0,0 +1,57 @@
+package a
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+type User struct {
+	ID    int
+	Email *string           // want `migrate User.Email to optional.Optional\[string\]`
+	Age   *int              // want `migrate User.Age to optional.Optional\[int\]`
+	Name  sql.NullString    // want `migrate User.Name to optional.Optional\[string\]`
+	Score sql.Null[float64] // want `migrate User.Score to optional.Optional\[float64\]`
+	Other *int
+}
+
+func NewUser(email string, age *int) User {
+	return User{
+		Email: &email,                                   // want `rewrite address assigned to User.Email to optional.Of`
+		Age:   age,                                      // want `rewrite pointer assigned to User.Age to optional.OfPtr`
+		Name:  sql.NullString{String: "x", Valid: true}, // want `rewrite value assigned to User.Name to optional.Of`
+		Score: sql.Null[float64]{},                      // want `rewrite empty value assigned to User.Score to optional.Empty`
+	}
+}
+
+func (u *User) Clear() {
+	u.Email = nil // want `rewrite empty value assigned to User.Email to optional.Empty`
+}
+
+func (u User) Print() {
+	if u.Email != nil { // want `rewrite nil check of User.Email to Get`
+		fmt.Println(*u.Email)
+	}
+	if u.Age == nil { // want `rewrite nil comparison of User.Age to IsPresent`
+		fmt.Println("no age")
+	}
+	if u.Name.Valid { // want `rewrite User.Name.Valid to IsPresent`
+		fmt.Println(u.Name.String) // want `rewrite User.Name.String to ElseZero`
+	}
+	fmt.Println(u.Score.V) // want `rewrite User.Score.V to ElseZero`
+	fmt.Println(u.Other)
+}
+
+func (u *User) Birthday() {
+	if u.Age != nil { // want `rewrite nil comparison of User.Age to IsPresent`
+		*u.Age++ // want `cannot rewrite dereference of User.Age; migrate it by hand`
+	}
+	fmt.Println(*u.Age) // want `cannot rewrite dereference of User.Age; migrate it by hand`
+}
+
+func Scan(rows *sql.Rows, u *User) error {
+	return rows.Scan(&u.ID, &u.Name)
+}
+
+func Addr(u *User) **int {
+	return &u.Age // want `cannot rewrite this use of User.Age; migrate it by hand`
+}
@@ -0,0 +1,11 @@
+package b
+
+import "a"
+
+func HasEmail(u a.User) bool {
+	return u.Email != nil // want `rewrite nil comparison of User.Email to IsPresent`
+}
+
+func SetAge(u *a.User, age int) {
+	u.Age = &age // want `rewrite address assigned to User.Age to optional.Of`
+}
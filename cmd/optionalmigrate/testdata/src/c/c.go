@@ -0,0 +1,14 @@
+package c
+
+import "database/sql"
+
+type Account struct {
+	Plan sql.NullString // want `migrate Account.Plan to optional.Optional\[string\]`
+}
+
+func Plan(a Account) string {
+	if !a.Plan.Valid { // want `rewrite Account.Plan.Valid to IsPresent`
+		return "free"
+	}
+	return a.Plan.String // want `rewrite Account.Plan.String to ElseZero`
+}
@@ -0,0 +1,63 @@
+//go:build go1.21
+
+package optional
+
+import "cmp"
+
+// EmptyOrder is where empty optionals are ordered relative to optionals that
+// wrap a value, like NULLS FIRST and NULLS LAST in SQL.
+type EmptyOrder int
+
+const (
+	// EmptiesFirst orders empty optionals before optionals that wrap a value.
+	EmptiesFirst EmptyOrder = iota
+	// EmptiesLast orders empty optionals after optionals that wrap a value.
+	EmptiesLast
+)
+
+// Compare returns -1 if a is less than b, 0 if a equals b, and +1 if a is
+// greater than b. Values wrapped are compared with cmp.Compare. Empty
+// optionals are equal to each other, and are ordered before or after
+// optionals that wrap a value as specified by the order.
+func Compare[T cmp.Ordered](a, b Optional[T], order EmptyOrder) int {
+	return CompareFunc(a, b, cmp.Compare[T], order)
+}
+
+// CompareFunc is like Compare, but compares the values wrapped with the
+// function. The function is only called if both optionals wrap a value.
+func CompareFunc[T, U any](a Optional[T], b Optional[U], cmp func(a T, b U) int, order EmptyOrder) int {
+	av, aok := a.Get()
+	bv, bok := b.Get()
+	switch {
+	case aok && bok:
+		return cmp(av, bv)
+	case aok == bok:
+		return 0
+	case aok == (order == EmptiesFirst):
+		return +1
+	default:
+		return -1
+	}
+}
+
+// CompareBy returns a comparison function for values of type S, that compares
+// the optionals returned by the key function with Compare. It is intended for
+// use with slices.SortFunc and similar functions to sort records by an
+// optional field:
+//
+//	slices.SortFunc(users, optional.CompareBy(func(u User) optional.Optional[int] {
+//		return u.Age
+//	}, optional.EmptiesLast))
+func CompareBy[S any, T cmp.Ordered](key func(S) Optional[T], order EmptyOrder) func(a, b S) int {
+	return func(a, b S) int {
+		return Compare(key(a), key(b), order)
+	}
+}
+
+// CompareByFunc is like CompareBy, but compares the values wrapped with the
+// function.
+func CompareByFunc[S, T any](key func(S) Optional[T], cmp func(a, b T) int, order EmptyOrder) func(a, b S) int {
+	return func(a, b S) int {
+		return CompareFunc(key(a), key(b), cmp, order)
+	}
+}
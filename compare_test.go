@@ -0,0 +1,107 @@
+//go:build go1.21
+
+package optional
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		A, B                 Optional[int]
+		ExpectedEmptiesFirst int
+		ExpectedEmptiesLast  int
+	}{
+		{Empty[int](), Empty[int](), 0, 0},
+		{Empty[int](), Of(0), -1, +1},
+		{Of(0), Empty[int](), +1, -1},
+		{Of(0), Of(0), 0, 0},
+		{Of(0), Of(1), -1, -1},
+		{Of(1), Of(0), +1, +1},
+	}
+
+	for _, test := range tests {
+		first := Compare(test.A, test.B, EmptiesFirst)
+		last := Compare(test.A, test.B, EmptiesLast)
+		if first != test.ExpectedEmptiesFirst || last != test.ExpectedEmptiesLast {
+			t.Errorf("Compare(%#v, %#v) got %#v, %#v, want %#v, %#v", test.A, test.B, first, last, test.ExpectedEmptiesFirst, test.ExpectedEmptiesLast)
+		}
+	}
+}
+
+func TestCompareFunc(t *testing.T) {
+	called := false
+	c := CompareFunc(Of("a"), Of("A"), func(a, b string) int {
+		called = true
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}, EmptiesFirst)
+	if !called || c != 0 {
+		t.Errorf("CompareFunc got %#v, %#v, want %#v, %#v", called, c, true, 0)
+	}
+
+	called = false
+	c = CompareFunc(Of("a"), Empty[string](), func(a, b string) int {
+		called = true
+		return 0
+	}, EmptiesFirst)
+	if called || c != +1 {
+		t.Errorf("CompareFunc got %#v, %#v, want %#v, %#v", called, c, false, +1)
+	}
+}
+
+type record struct {
+	Name string
+	Age  Optional[int]
+}
+
+func TestCompareBy(t *testing.T) {
+	records := []record{
+		{"a", Of(30)},
+		{"b", Empty[int]()},
+		{"c", Of(20)},
+		{"d", Empty[int]()},
+		{"e", Of(40)},
+	}
+	age := func(r record) Optional[int] { return r.Age }
+
+	tests := []struct {
+		Order    EmptyOrder
+		Expected string
+	}{
+		{EmptiesFirst, "bdcae"},
+		{EmptiesLast, "caebd"},
+	}
+
+	for _, test := range tests {
+		sorted := slices.Clone(records)
+		slices.SortStableFunc(sorted, CompareBy(age, test.Order))
+		names := ""
+		for _, r := range sorted {
+			names += r.Name
+		}
+		if names != test.Expected {
+			t.Errorf("CompareBy(%#v) sorted %s, want %s", test.Order, names, test.Expected)
+		}
+	}
+}
+
+func TestCompareByFunc(t *testing.T) {
+	records := []record{
+		{"a", Of(30)},
+		{"b", Empty[int]()},
+		{"c", Of(20)},
+	}
+	age := func(r record) Optional[int] { return r.Age }
+	descending := func(a, b int) int { return b - a }
+
+	slices.SortStableFunc(records, CompareByFunc(age, descending, EmptiesLast))
+	names := ""
+	for _, r := range records {
+		names += r.Name
+	}
+	if names != "acb" {
+		t.Errorf("CompareByFunc sorted %s, want %s", names, "acb")
+	}
+}
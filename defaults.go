@@ -0,0 +1,94 @@
+package optional
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// ApplyDefaults sets the empty optional fields of the struct pointed to by v
+// to the values in their default tags:
+//
+//	type Config struct {
+//		Timeout optional.Optional[time.Duration] `default:"30s"`
+//	}
+//
+// Default values are parsed like flags set with FlagValue. Nested structs,
+// pointers to structs, and slices and arrays of them, are walked too.
+// Optional fields that are present, and optionals that are not fields of a
+// struct, are left as they are.
+//
+// Every default tag walked is parsed, even if the field is present, so that
+// malformed defaults are found. The error returned names the path of the
+// field with the malformed default, such as Config.Servers[1].Timeout. An
+// error is also returned for a default tag on a field that is not an optional.
+func ApplyDefaults(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("optional: cannot apply defaults to %T, want a non-nil pointer to a struct", v)
+	}
+	return applyDefaults(rv.Elem(), rv.Elem().Type().Name())
+}
+
+// defaultSetter is implemented by pointers to optionals.
+type defaultSetter interface {
+	textSetter
+	IsPresent() bool
+}
+
+func applyDefaults(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return applyDefaults(v.Elem(), path)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			err := applyDefaults(v.Index(i), path+"["+strconv.Itoa(i)+"]")
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fieldPath := f.Name
+		if path != "" {
+			fieldPath = path + "." + f.Name
+		}
+		fv := v.Field(i)
+		tag, ok := f.Tag.Lookup("default")
+		if !ok {
+			if _, isOptional := fv.Addr().Interface().(defaultSetter); !isOptional {
+				err := applyDefaults(fv, fieldPath)
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		o, isOptional := fv.Addr().Interface().(defaultSetter)
+		if !isOptional {
+			return fmt.Errorf("optional: default for %s: field of type %s is not an optional", fieldPath, f.Type)
+		}
+		d := reflect.New(f.Type)
+		err := d.Interface().(defaultSetter).setText(tag)
+		if err != nil {
+			return fmt.Errorf("optional: default for %s: %w", fieldPath, err)
+		}
+		if !o.IsPresent() {
+			fv.Set(d.Elem())
+		}
+	}
+	return nil
+}
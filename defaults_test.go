@@ -0,0 +1,130 @@
+package optional
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type defaultsConfig struct {
+	Name     Optional[string]        `default:"app"`
+	Port     Optional[int]           `default:"8080"`
+	Timeout  Optional[time.Duration] `default:"30s"`
+	Level    Optional[level]         `default:"2"`
+	Empty    Optional[string]        `default:""`
+	Untagged Optional[int]
+	Workers  int
+	Server   defaultsServer
+	Backup   *defaultsServer
+	Servers  []defaultsServer
+}
+
+type defaultsServer struct {
+	Host Optional[string] `default:"localhost"`
+	Port Optional[int]    `default:"80"`
+}
+
+func TestApplyDefaults(t *testing.T) {
+	c := defaultsConfig{
+		Port:    Of(9000),
+		Workers: 4,
+		Backup:  &defaultsServer{Host: Of("backup")},
+		Servers: []defaultsServer{
+			{Host: Of("a")},
+			{Port: Of(81)},
+		},
+	}
+
+	err := ApplyDefaults(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Name     string
+		Got      any
+		Expected any
+	}{
+		{"Name", c.Name, Of("app")},
+		{"Port", c.Port, Of(9000)},
+		{"Timeout", c.Timeout, Of(30 * time.Second)},
+		{"Level", c.Level, Of(level(2))},
+		{"Empty", c.Empty, Of("")},
+		{"Untagged", c.Untagged, Empty[int]()},
+		{"Workers", c.Workers, 4},
+		{"Server", c.Server, defaultsServer{Host: Of("localhost"), Port: Of(80)}},
+		{"Backup", *c.Backup, defaultsServer{Host: Of("backup"), Port: Of(80)}},
+		{"Servers[0]", c.Servers[0], defaultsServer{Host: Of("a"), Port: Of(80)}},
+		{"Servers[1]", c.Servers[1], defaultsServer{Host: Of("localhost"), Port: Of(81)}},
+	}
+
+	for _, test := range tests {
+		if test.Got != test.Expected {
+			t.Errorf("%s got %#v, want %#v", test.Name, test.Got, test.Expected)
+		}
+	}
+}
+
+func TestApplyDefaultsNilPointer(t *testing.T) {
+	c := defaultsConfig{}
+	err := ApplyDefaults(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Backup != nil {
+		t.Errorf("Backup got %#v, want nil", c.Backup)
+	}
+}
+
+type malformedServer struct {
+	Port Optional[int] `default:"eighty"`
+}
+
+type malformedConfig struct {
+	Servers []malformedServer
+}
+
+type notOptionalConfig struct {
+	Workers int `default:"4"`
+}
+
+func TestApplyDefaultsErrors(t *testing.T) {
+	tests := []struct {
+		Value         any
+		ExpectedError string
+	}{
+		{
+			&malformedServer{Port: Of(80)},
+			`optional: default for malformedServer.Port: strconv.ParseInt: parsing "eighty": invalid syntax`,
+		},
+		{
+			&malformedConfig{Servers: []malformedServer{{}, {}}},
+			`optional: default for malformedConfig.Servers[0].Port: strconv.ParseInt: parsing "eighty": invalid syntax`,
+		},
+		{
+			&notOptionalConfig{},
+			`optional: default for notOptionalConfig.Workers: field of type int is not an optional`,
+		},
+		{
+			malformedServer{},
+			`optional: cannot apply defaults to optional.malformedServer, want a non-nil pointer to a struct`,
+		},
+		{
+			(*malformedServer)(nil),
+			`optional: cannot apply defaults to *optional.malformedServer, want a non-nil pointer to a struct`,
+		},
+	}
+
+	for _, test := range tests {
+		err := ApplyDefaults(test.Value)
+		if err == nil || err.Error() != test.ExpectedError {
+			t.Errorf("ApplyDefaults(%T) got %v, want %v", test.Value, err, test.ExpectedError)
+		}
+	}
+
+	var numErr *strconv.NumError
+	if err := ApplyDefaults(&malformedServer{}); !errors.As(err, &numErr) {
+		t.Errorf("ApplyDefaults got %v, want error wrapping %T", err, numErr)
+	}
+}
@@ -1,7 +1,7 @@
 /*
-Package optional exports an Optional[T] type that can wrap any type to represent the lack of value. The types guarantee safety by requiring the developer to unwrap them to get to the inner value. This prevents a nil value being operated on. Optionals marshal to XML and JSON like their underlying type, and omitempty works just like their wrapped type would with a pointer, but without the use of pointers.
+Package optional exports an Optional[T] type that can wrap any type to represent the lack of value. The types guarantee safety by requiring the developer to unwrap them to get to the inner value. This prevents a nil value being operated on. Optionals marshal to XML and JSON like their underlying type. Empty optionals are omitted from XML, and from JSON when the field has the `omitzero` option, otherwise they marshal to JSON as null. Optionals hold their value inline, so wrapping a value does not allocate.
 
-These types are an alternative to using pointers, zero values, or similar null wrapper packages. Unlike similar solutions these will omit correctly from XML and JSON without the use of pointers and the compiler will ensure their value is not used when empty.
+These types are an alternative to using pointers, zero values, or similar null wrapper packages. Unlike similar solutions these can be omitted from XML and JSON without the use of pointers and the compiler will ensure their value is not used when empty.
 
 # Examples
 
@@ -40,12 +40,61 @@ Or get it's value with a fallback to a default:
 		return 100
 	})
 
-XML and JSON are supported out of the box. Use `omitempty` to omit the field when the optional is empty:
+Or convert it back to a pointer:
+
+	_ := o.Ptr() // returns a pointer to a copy of the value, or nil if empty
+
+Transform it without unwrapping it:
+
+	s := optional.Map(o, func(i int) string {
+		// called if o is not empty
+		return strconv.Itoa(i)
+	})
+
+	_ := o.Or(optional.Of(100)) // returns o, or an optional of 100 if o is empty
+
+Compare it with another optional, where empty optionals are equal to each other:
+
+	_ := optional.Equal(o, optional.Of(100)) // returns true if o wraps 100
+
+Optionals of comparable types are comparable with == and can be map keys, and an empty optional is never equal to an optional of the zero value:
+
+	_ := optional.Empty[int]() == optional.Of(0) // false
+
+With Go 1.21 or later, order optionals with empties first or last, like NULLS FIRST and NULLS LAST in SQL:
+
+	slices.SortFunc(users, optional.CompareBy(func(u User) optional.Optional[int] {
+		return u.Age
+	}, optional.EmptiesLast))
+
+Print it with %+v or %#v to tell an empty optional apart from one wrapping the zero value, which both print the zero value with %v:
+
+	fmt.Printf("%+v", o) // prints Some(0) or None
+
+	fmt.Printf("%#v", o) // prints optional.Of[int](0) or optional.Empty[int]()
+
+With Go 1.23 or later, range over it, which runs zero or one times:
+
+	for i := range o.All() {
+		// called if o is not empty
+	}
+
+Keep the error when converting a value and error into a single value with Result[T]:
+
+	r := optional.ResultOf(strconv.Atoi(s))
+
+	if i, ok := r.Get(); ok {
+		// ok is true if r holds a value
+	} else if err := r.Err(); err != nil {
+		// err is the error returned by strconv.Atoi
+	}
+
+XML and JSON are supported out of the box. With Go 1.24 or later, use `omitzero` to omit the field from JSON when the optional is empty:
 
 	s := struct {
-		Int1 optional.Optional[int] `json:"int1,omitempty"`
-		Int2 optional.Optional[int] `json:"int2,omitempty"`
-		Int3 optional.Optional[int] `json:"int3,omitempty"`
+		Int1 optional.Optional[int] `json:"int1,omitzero"`
+		Int2 optional.Optional[int] `json:"int2,omitzero"`
+		Int3 optional.Optional[int] `json:"int3,omitzero"`
 	}{
 		Int1: optional.Empty[int](),
 		Int2: optional.Of(1000),
@@ -55,5 +104,79 @@ XML and JSON are supported out of the box. Use `omitempty` to omit the field whe
 	output, _ := json.Marshal(s)
 
 	// output = {"int2":1000}
+
+Without `omitzero` an empty optional marshals to JSON as null, and null unmarshals to an empty optional. Use OrZero[T] for fields that should marshal the zero value instead, and unmarshal null as the zero value.
+
+For JSON documents where a missing field and a null field mean different things, such as merge-patch request bodies, use Field[T] which tracks whether the field is undefined, null, or set to a value:
+
+	s := struct {
+		Name optional.Field[string] `json:"name,omitzero"`
+	}{}
+
+	_ = json.Unmarshal([]byte(`{"name":null}`), &s)
+
+	// s.Name.IsDefined() = true
+	// s.Name.IsNull() = true
+
+With Go 1.22 or later, optionals implement sql.Scanner and driver.Valuer, so they can be scanned from and written to database columns. SQL NULL is scanned as an empty optional, and an empty optional is written as NULL:
+
+	var name optional.Optional[string]
+	err := db.QueryRow("SELECT name FROM users WHERE id = ?", id).Scan(&name)
+
+Optionals implement encoding.TextMarshaler and encoding.TextUnmarshaler, using the wrapped type's own text methods if it has them, or strconv for basic kinds, so they can be JSON map keys. An empty optional has the empty string as its text form, which is the same as the text form of an optional wrapping an empty string. A map[Optional[string]]V holding both keys marshals to JSON with the key "" twice, and unmarshals both to the empty optional, so use Optional[string] keys only when the empty string is never a value.
+
+Optionals can also be XML attributes, which are omitted when the optional is empty:
+
+	s := struct {
+		ID optional.Optional[int] `xml:"id,attr"`
+	}{}
+
+Empty optionals are omitted from XML elements too. Use Nillable[T] for elements that should marshal as an element with xsi:nil="true" instead, and declare the xsi prefix on the root element with XSINamespace. Elements with xsi:nil="true" always unmarshal to an empty optional.
+
+With Go 1.21 or later, optionals implement slog.LogValuer, so they are logged with log/slog as the value they wrap. Empty optionals are logged as null. Use LogValueOr at the call site to drop them or log a sentinel instead.
+
+# Configuration and validation
+
+Use FlagVar to define a command-line flag that sets an optional, so that IsPresent reports whether the flag was set:
+
+	var port optional.Optional[int]
+	optional.FlagVar(flag.CommandLine, &port, "port", "the port to listen on")
+
+Use package 4d63.com/optional/env to load environment variables into a struct of optionals, which are left empty when their variable is not set:
+
+	var c struct {
+		Port optional.Optional[int] `env:"PORT"`
+	}
+	err := env.Load("APP", &c) // loads APP_PORT
+
+Merge layers of config held in structs of optionals, where each field is taken from the last layer that sets it, and use MergeSources to find out which layer that was:
+
+	c := optional.Merge(defaults, file, env, flags)
+
+Set empty optional fields to defaults declared in struct tags with ApplyDefaults:
+
+	s := struct {
+		Timeout optional.Optional[time.Duration] `default:"30s"`
+	}{}
+
+	err := optional.ApplyDefaults(&s)
+
+Use package 4d63.com/optional/validate to check structs of optionals against rules in validate tags, with every failure reported with the JSON pointer of its field:
+
+	s := struct {
+		Name optional.Field[string] `json:"name" validate:"required,max=100"`
+	}{}
+
+	err := validate.Struct(s) // err lists /name is required
+
+Use package 4d63.com/optional/jsonschema to generate a JSON Schema for a type, where optional fields are not required and allow null:
+
+	s, err := jsonschema.For[User]()
+
+# Migrating from omitempty
+
+Optionals were previously slices, and relied on `omitempty` omitting empty slices. Optionals are now structs, so that their value cannot be accessed without their methods and wrapping a value no longer allocates. `omitempty` no longer omits empty optionals from JSON, and cannot be made to: encoding/json never applies `omitempty` to structs, and does not ask the type. To keep empty optionals out of JSON, replace `omitempty` with `omitzero` on Optional[T] and Field[T] fields, which requires Go 1.24 or later. With older versions of Go, or without `omitzero`, empty optionals marshal to JSON as null.
+
+Empty optionals are omitted from XML whether or not the field has `omitempty`. This is a deliberate change for fields without `omitempty`, where empty optionals were previously marshaled as the zero value of their type. encoding/xml has no equivalent of `omitzero` and never applies `omitempty` to structs, so an optional cannot tell the two kinds of field apart, and omitting keeps fields with `omitempty` unchanged. To keep marshaling the zero value, change those fields to OrZero[T].
 */
 package optional
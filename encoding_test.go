@@ -0,0 +1,137 @@
+//go:build go1.24
+
+package optional
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestOpaque(t *testing.T) {
+	tests := []reflect.Type{
+		reflect.TypeOf(Optional[int]{}),
+		reflect.TypeOf(Field[int]{}),
+		reflect.TypeOf(Result[int]{}),
+	}
+
+	for _, typ := range tests {
+		if typ.Kind() != reflect.Struct {
+			t.Errorf("%v kind got %v, want %v", typ, typ.Kind(), reflect.Struct)
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			if f := typ.Field(i); f.IsExported() {
+				t.Errorf("%v has exported field %s, want only unexported fields", typ, f.Name)
+			}
+		}
+	}
+}
+
+func TestJSONEncoding(t *testing.T) {
+	type s struct {
+		None     Optional[int] `json:"none"`
+		OmitZero Optional[int] `json:"omitzero,omitzero"`
+	}
+	tests := []struct {
+		Value           s
+		ExpectedMarshal string
+	}{
+		{s{}, `{"none":null}`},
+		{s{Of(0), Of(0)}, `{"none":0,"omitzero":0}`},
+		{s{Of(1), Of(2)}, `{"none":1,"omitzero":2}`},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.Value)
+
+		if err != nil || string(data) != test.ExpectedMarshal {
+			t.Errorf("%#v json.Marshal got %#v, %v, want %#v", test.Value, string(data), err, test.ExpectedMarshal)
+		}
+	}
+}
+
+func TestJSONEncodingField(t *testing.T) {
+	type s struct {
+		None     Field[int] `json:"none"`
+		OmitZero Field[int] `json:"omitzero,omitzero"`
+	}
+	tests := []struct {
+		Value           s
+		ExpectedMarshal string
+	}{
+		{s{}, `{"none":null}`},
+		{s{Null[int](), Null[int]()}, `{"none":null,"omitzero":null}`},
+		{s{FieldOf(0), FieldOf(0)}, `{"none":0,"omitzero":0}`},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.Value)
+
+		if err != nil || string(data) != test.ExpectedMarshal {
+			t.Errorf("%#v json.Marshal got %#v, %v, want %#v", test.Value, string(data), err, test.ExpectedMarshal)
+		}
+	}
+}
+
+func TestXMLEncoding(t *testing.T) {
+	type s struct {
+		XMLName   xml.Name      `xml:"s"`
+		None      Optional[int] `xml:"none"`
+		OmitEmpty Optional[int] `xml:"omitempty,omitempty"`
+		Attr      Optional[int] `xml:"attr,attr"`
+	}
+	tests := []struct {
+		Value           s
+		ExpectedMarshal string
+	}{
+		{s{}, `<s></s>`},
+		{s{None: Of(0), OmitEmpty: Of(0), Attr: Of(0)}, `<s attr="0"><none>0</none><omitempty>0</omitempty></s>`},
+		{s{None: Of(1), OmitEmpty: Of(2), Attr: Of(3)}, `<s attr="3"><none>1</none><omitempty>2</omitempty></s>`},
+	}
+
+	for _, test := range tests {
+		data, err := xml.Marshal(test.Value)
+
+		if err != nil || string(data) != test.ExpectedMarshal {
+			t.Errorf("%#v xml.Marshal got %#v, %v, want %#v", test.Value, string(data), err, test.ExpectedMarshal)
+		}
+	}
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	type s struct {
+		XMLName xml.Name      `json:"-" xml:"s"`
+		Empty   Optional[int] `json:"empty,omitzero" xml:"empty"`
+		Zero    Optional[int] `json:"zero,omitzero" xml:"zero"`
+		One     Optional[int] `json:"one,omitzero" xml:"one,attr"`
+	}
+	in := s{XMLName: xml.Name{Local: "s"}, Zero: Of(0), One: Of(1)}
+
+	jsonData, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromJSON s
+	err = json.Unmarshal(jsonData, &fromJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON.XMLName = in.XMLName
+	if fromJSON != in {
+		t.Errorf("json round trip got %#v, want %#v", fromJSON, in)
+	}
+
+	xmlData, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromXML s
+	err = xml.Unmarshal(xmlData, &fromXML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromXML != in {
+		t.Errorf("xml round trip got %#v, want %#v", fromXML, in)
+	}
+}
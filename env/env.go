@@ -0,0 +1,218 @@
+// Package env loads environment variables into the fields of a struct, using
+// optionals for variables that may not be set.
+//
+// Fields are loaded from the variable named in their env tag:
+//
+//	type Config struct {
+//		Addr    optional.Optional[string]        `env:"ADDR"`
+//		Timeout optional.Optional[time.Duration] `env:"TIMEOUT"`
+//		DB      struct {
+//			Host optional.Optional[string] `env:"HOST"`
+//			Port optional.Optional[int]    `env:"PORT"`
+//		} `env:"DB"`
+//	}
+//
+//	var c Config
+//	err := env.Load("APP", &c)
+//
+// An optional field is set if its variable is set, even if it is set to the
+// empty string, and is left as it is if the variable is not set. Fields that
+// are not optionals are set if their variable is set. Values are parsed with
+// the UnmarshalText method of the type if it implements
+// encoding.TextUnmarshaler, otherwise values of the basic kinds are parsed
+// using strconv.
+//
+// Variable names are prefixed with the prefix passed to Load and the env tags
+// of the struct fields they are nested in, joined with underscores. The
+// variables of the example are APP_ADDR, APP_TIMEOUT, APP_DB_HOST and
+// APP_DB_PORT. Struct fields without an env tag are loaded without adding to
+// the prefix. Pointers to structs are loaded like structs, and a nil pointer is
+// set to a new struct only if one of its variables is set. Pointers to a struct
+// that is already being loaded are not loaded, as they would nest without end.
+// Other fields without an env tag, and fields with the tag env:"-", are not
+// loaded.
+//
+// A tagged field of a type that cannot be loaded from a variable, such as
+// optional.Field or optional.Result, is an error whether or not its variable
+// is set.
+package env
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"4d63.com/optional/internal/textenc"
+)
+
+// VarError is an error parsing the value of an environment variable, or an
+// error for a tagged field of a type that cannot be loaded from a variable.
+type VarError struct {
+	Name string
+	Err  error
+}
+
+func (e *VarError) Error() string {
+	return fmt.Sprintf("env: %s: %v", e.Name, e.Err)
+}
+
+func (e *VarError) Unwrap() error {
+	return e.Err
+}
+
+// Errors is the error returned by Load when variables cannot be parsed or
+// fields cannot be loaded. It holds an error for every variable that could not
+// be parsed, and every tagged field that could not be loaded.
+type Errors []*VarError
+
+func (e Errors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Load sets the fields of the struct pointed to by v from the environment
+// variables named in their env tags, prefixed with the prefix if it is not
+// empty. Every field whose variable is set is loaded, even if other variables
+// cannot be parsed. If any variables cannot be parsed, or any tagged fields
+// cannot be loaded, an Errors is returned.
+func Load(prefix string, v any) error {
+	return LoadFunc(prefix, v, os.LookupEnv)
+}
+
+// LoadFunc is like Load, but looks up variables with the function instead of
+// os.LookupEnv.
+func LoadFunc(prefix string, v any, lookup func(name string) (string, bool)) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("env: cannot load into %T, want a non-nil pointer to a struct", v)
+	}
+	l := loader{lookup: lookup, visiting: map[reflect.Type]bool{}}
+	l.loadStruct(prefix, rv.Elem())
+	if len(l.errs) > 0 {
+		return l.errs
+	}
+	return nil
+}
+
+type loader struct {
+	lookup   func(name string) (string, bool)
+	errs     Errors
+	visiting map[reflect.Type]bool
+}
+
+// loadStruct loads the fields of the struct, and returns true if any of their
+// variables were set.
+func (l *loader) loadStruct(prefix string, v reflect.Value) (loaded bool) {
+	t := v.Type()
+	l.visiting[t] = true
+	defer delete(l.visiting, t)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag, tagged := f.Tag.Lookup("env")
+		if tag == "-" {
+			continue
+		}
+		fv := v.Field(i)
+		p := prefix
+		if tagged {
+			p = join(prefix, tag)
+		}
+		if isStruct(f.Type) {
+			if l.loadStruct(p, fv) {
+				loaded = true
+			}
+			continue
+		}
+		if f.Type.Kind() == reflect.Pointer && isStruct(f.Type.Elem()) {
+			if l.visiting[f.Type.Elem()] {
+				continue
+			}
+			// A nil pointer is only set if a variable of the struct it
+			// points to is set.
+			elem := fv
+			if fv.IsNil() {
+				elem = reflect.New(f.Type.Elem())
+			}
+			if l.loadStruct(p, elem.Elem()) {
+				fv.Set(elem)
+				loaded = true
+			}
+			continue
+		}
+		if !tagged {
+			continue
+		}
+		name := p
+		if !canLoad(f.Type) {
+			l.errs = append(l.errs, &VarError{Name: name, Err: fmt.Errorf("cannot load into field %s of type %s", f.Name, f.Type)})
+			continue
+		}
+		text, ok := l.lookup(name)
+		if !ok {
+			continue
+		}
+		loaded = true
+		err := set(fv, text)
+		if err != nil {
+			l.errs = append(l.errs, &VarError{Name: name, Err: err})
+		}
+	}
+	return loaded
+}
+
+// isStruct returns true if the type is a struct that is loaded field by field,
+// rather than from a single variable. Structs without exported fields, such as
+// optional.Field and optional.Result, have no fields to load.
+func isStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || canLoad(t) {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// canLoad returns true if a value of the type can be loaded from a single
+// variable.
+func canLoad(t reflect.Type) bool {
+	if _, ok := textenc.Setter(reflect.New(t).Interface()); ok {
+		return true
+	}
+	return textenc.CanUnmarshal(t)
+}
+
+// set parses the text into the value.
+func set(v reflect.Value, text string) error {
+	if set, ok := textenc.Setter(v.Addr().Interface()); ok {
+		return set(text)
+	}
+	return textenc.Unmarshal([]byte(text), v.Addr().Interface())
+}
+
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	if name == "" {
+		return prefix
+	}
+	return prefix + "_" + name
+}
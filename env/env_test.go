@@ -0,0 +1,235 @@
+package env
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"4d63.com/optional"
+)
+
+type config struct {
+	Name     optional.Optional[string]        `env:"NAME"`
+	Port     optional.Optional[int]           `env:"PORT"`
+	Debug    optional.Optional[bool]          `env:"DEBUG"`
+	Timeout  optional.Optional[time.Duration] `env:"TIMEOUT"`
+	Started  optional.Optional[time.Time]     `env:"STARTED"`
+	Workers  int                              `env:"WORKERS"`
+	Ignored  optional.Optional[string]        `env:"-"`
+	Untagged optional.Optional[string]
+	DB       database `env:"DB"`
+	Cache    struct {
+		Size optional.Optional[int] `env:"CACHE_SIZE"`
+	}
+}
+
+type database struct {
+	Host optional.Optional[string] `env:"HOST"`
+	Port optional.Optional[int]    `env:"PORT"`
+}
+
+func lookup(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("TEST_NAME", "")
+	t.Setenv("TEST_PORT", "8080")
+	t.Setenv("TEST_DB_HOST", "localhost")
+
+	var c config
+	err := Load("TEST", &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != optional.Of("") {
+		t.Errorf("Name got %#v, want %#v", c.Name, optional.Of(""))
+	}
+	if c.Port != optional.Of(8080) {
+		t.Errorf("Port got %#v, want %#v", c.Port, optional.Of(8080))
+	}
+	if c.Debug.IsPresent() {
+		t.Errorf("Debug got %#v, want %#v", c.Debug, optional.Empty[bool]())
+	}
+	if c.DB.Host != optional.Of("localhost") {
+		t.Errorf("DB.Host got %#v, want %#v", c.DB.Host, optional.Of("localhost"))
+	}
+	if c.DB.Port.IsPresent() {
+		t.Errorf("DB.Port got %#v, want %#v", c.DB.Port, optional.Empty[int]())
+	}
+}
+
+func TestLoadFunc(t *testing.T) {
+	vars := map[string]string{
+		"NAME":       "a",
+		"PORT":       "8080",
+		"DEBUG":      "true",
+		"TIMEOUT":    "30s",
+		"STARTED":    "2006-01-02T15:04:05Z",
+		"WORKERS":    "4",
+		"Ignored":    "x",
+		"Untagged":   "x",
+		"DB_HOST":    "localhost",
+		"DB_PORT":    "5432",
+		"CACHE_SIZE": "100",
+	}
+
+	var c config
+	err := LoadFunc("", &c, lookup(vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := config{
+		Name:    optional.Of("a"),
+		Port:    optional.Of(8080),
+		Debug:   optional.Of(true),
+		Timeout: optional.Of(30 * time.Second),
+		Started: optional.Of(time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)),
+		Workers: 4,
+		DB: database{
+			Host: optional.Of("localhost"),
+			Port: optional.Of(5432),
+		},
+	}
+	want.Cache.Size = optional.Of(100)
+	if c != want {
+		t.Errorf("LoadFunc got %+v, want %+v", c, want)
+	}
+}
+
+func TestLoadFuncUnset(t *testing.T) {
+	c := config{Port: optional.Of(80), Workers: 1}
+	err := LoadFunc("APP", &c, lookup(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := config{Port: optional.Of(80), Workers: 1}
+	if c != want {
+		t.Errorf("LoadFunc got %+v, want %+v", c, want)
+	}
+}
+
+func TestLoadFuncErrors(t *testing.T) {
+	vars := map[string]string{
+		"APP_NAME":    "a",
+		"APP_PORT":    "x",
+		"APP_TIMEOUT": "30",
+		"APP_WORKERS": "",
+		"APP_DB_HOST": "localhost",
+	}
+
+	var c config
+	err := LoadFunc("APP", &c, lookup(vars))
+
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("LoadFunc got %v, want Errors", err)
+	}
+	names := []string{}
+	for _, e := range errs {
+		names = append(names, e.Name)
+	}
+	wantNames := []string{"APP_PORT", "APP_TIMEOUT", "APP_WORKERS"}
+	if len(names) != len(wantNames) {
+		t.Fatalf("LoadFunc got errors for %q, want %q", names, wantNames)
+	}
+	for i := range names {
+		if names[i] != wantNames[i] {
+			t.Errorf("LoadFunc got errors for %q, want %q", names, wantNames)
+		}
+	}
+
+	var numErr *strconv.NumError
+	if !errors.As(errs[0], &numErr) {
+		t.Errorf("LoadFunc got %T for APP_PORT, want %T", errs[0].Err, numErr)
+	}
+	wantErr := `env: APP_PORT: strconv.ParseInt: parsing "x": invalid syntax` + "\n" +
+		`env: APP_TIMEOUT: time: missing unit in duration "30"` + "\n" +
+		`env: APP_WORKERS: strconv.ParseInt: parsing "": invalid syntax`
+	if err.Error() != wantErr {
+		t.Errorf("LoadFunc got error %q, want %q", err.Error(), wantErr)
+	}
+
+	if c.Name != optional.Of("a") || c.DB.Host != optional.Of("localhost") {
+		t.Errorf("LoadFunc got %+v, want variables that parsed to be loaded", c)
+	}
+}
+
+func TestLoadFuncUnsupported(t *testing.T) {
+	var c struct {
+		Name   optional.Optional[string] `env:"NAME"`
+		Field  optional.Field[string]    `env:"FIELD"`
+		Result optional.Result[int]      `env:"RESULT"`
+		Slice  []int                     `env:"SLICE"`
+	}
+	vars := map[string]string{
+		"APP_NAME":  "a",
+		"APP_FIELD": "b",
+	}
+
+	err := LoadFunc("APP", &c, lookup(vars))
+
+	wantErr := `env: APP_FIELD: cannot load into field Field of type optional.Field[string]` + "\n" +
+		`env: APP_RESULT: cannot load into field Result of type optional.Result[int]` + "\n" +
+		`env: APP_SLICE: cannot load into field Slice of type []int`
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("LoadFunc got error %v, want %q", err, wantErr)
+	}
+	if c.Name != optional.Of("a") {
+		t.Errorf("Name got %#v, want %#v", c.Name, optional.Of("a"))
+	}
+}
+
+func TestLoadFuncPointer(t *testing.T) {
+	type node struct {
+		Name optional.Optional[string] `env:"NAME"`
+		Next *node                     `env:"NEXT"`
+	}
+	var c struct {
+		DB    *database `env:"DB"`
+		Cache *database `env:"CACHE"`
+		Node  *node     `env:"NODE"`
+	}
+	vars := map[string]string{
+		"APP_DB_HOST":   "localhost",
+		"APP_NODE_NAME": "a",
+	}
+
+	err := LoadFunc("APP", &c, lookup(vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.DB == nil || c.DB.Host != optional.Of("localhost") || c.DB.Port.IsPresent() {
+		t.Errorf("DB got %+v, want Host localhost", c.DB)
+	}
+	if c.Cache != nil {
+		t.Errorf("Cache got %+v, want nil", c.Cache)
+	}
+	if c.Node == nil || c.Node.Name != optional.Of("a") || c.Node.Next != nil {
+		t.Errorf("Node got %+v, want Name a and nil Next", c.Node)
+	}
+}
+
+func TestLoadFuncInvalid(t *testing.T) {
+	tests := []any{
+		nil,
+		config{},
+		(*config)(nil),
+		new(int),
+	}
+
+	for _, test := range tests {
+		err := LoadFunc("", test, lookup(nil))
+		if err == nil {
+			t.Errorf("LoadFunc(%T) got nil error, want error", test)
+		}
+	}
+}
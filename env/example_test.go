@@ -0,0 +1,37 @@
+package env_test
+
+import (
+	"fmt"
+	"os"
+
+	"4d63.com/optional"
+	"4d63.com/optional/env"
+)
+
+func Example() {
+	os.Setenv("APP_PORT", "8080")
+	os.Setenv("APP_DB_HOST", "localhost")
+
+	var c struct {
+		Addr optional.Optional[string] `env:"ADDR"`
+		Port optional.Optional[int]    `env:"PORT"`
+		DB   struct {
+			Host optional.Optional[string] `env:"HOST"`
+		} `env:"DB"`
+	}
+
+	err := env.Load("APP", &c)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(c.Addr.IsPresent(), c.Addr.Else(":http"))
+	fmt.Println(c.Port.IsPresent(), c.Port)
+	fmt.Println(c.DB.Host.IsPresent(), c.DB.Host)
+
+	// Output:
+	// false :http
+	// true 8080
+	// true localhost
+}
@@ -0,0 +1,19 @@
+package optional
+
+// Equal returns true if both optionals are empty, or both wrap values that are
+// equal. It is equivalent to a == b.
+func Equal[T comparable](a, b Optional[T]) bool {
+	return EqualFunc(a, b, func(av, bv T) bool { return av == bv })
+}
+
+// EqualFunc returns true if both optionals are empty, or both wrap values for
+// which the function returns true. The function is only called if both
+// optionals wrap a value.
+func EqualFunc[T, U any](a Optional[T], b Optional[U], eq func(a T, b U) bool) bool {
+	av, aok := a.Get()
+	bv, bok := b.Get()
+	if aok && bok {
+		return eq(av, bv)
+	}
+	return aok == bok
+}
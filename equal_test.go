@@ -0,0 +1,97 @@
+package optional
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		A, B     Optional[int]
+		Expected bool
+	}{
+		{Empty[int](), Empty[int](), true},
+		{Empty[int](), Of(0), false},
+		{Of(0), Empty[int](), false},
+		{Of(0), Of(0), true},
+		{Of(0), Of(1), false},
+		{OfPtr[int](nil), Empty[int](), true},
+	}
+
+	for _, test := range tests {
+		equal := Equal(test.A, test.B)
+		if equal != test.Expected {
+			t.Errorf("Equal(%#v, %#v) got %#v, want %#v", test.A, test.B, equal, test.Expected)
+		}
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	tests := []struct {
+		A, B           Optional[string]
+		ExpectedCalled bool
+		Expected       bool
+	}{
+		{Empty[string](), Empty[string](), false, true},
+		{Empty[string](), Of(""), false, false},
+		{Of(""), Empty[string](), false, false},
+		{Of("a"), Of("A"), true, true},
+		{Of("a"), Of("b"), true, false},
+	}
+
+	for _, test := range tests {
+		called := false
+		equal := EqualFunc(test.A, test.B, func(a, b string) bool {
+			called = true
+			return strings.EqualFold(a, b)
+		})
+		if called != test.ExpectedCalled || equal != test.Expected {
+			t.Errorf("EqualFunc(%#v, %#v) got %#v, %#v, want %#v, %#v", test.A, test.B, called, equal, test.ExpectedCalled, test.Expected)
+		}
+	}
+}
+
+func TestComparable(t *testing.T) {
+	type key struct {
+		ID   int
+		Name Optional[string]
+	}
+
+	m := map[key]string{}
+	m[key{1, Empty[string]()}] = "empty"
+	m[key{1, Of("")}] = "zero"
+	m[key{1, Of("a")}] = "a"
+	m[key{1, OfPtr[string](nil)}] = "nil"
+
+	tests := []struct {
+		Key      key
+		Expected string
+	}{
+		{key{1, Empty[string]()}, "nil"},
+		{key{1, Of("")}, "zero"},
+		{key{1, Of("a")}, "a"},
+	}
+
+	if len(m) != 3 {
+		t.Errorf("map got %d keys, want %d", len(m), 3)
+	}
+	for _, test := range tests {
+		value := m[test.Key]
+		if value != test.Expected {
+			t.Errorf("map[%#v] got %#v, want %#v", test.Key, value, test.Expected)
+		}
+	}
+
+	if Empty[int]() == Of(0) {
+		t.Errorf("Empty[int]() == Of(0) got true, want false")
+	}
+	if Of(1) != Of(1) {
+		t.Errorf("Of(1) == Of(1) got false, want true")
+	}
+	if Empty[int]() != OfPtr[int](nil) {
+		t.Errorf("Empty[int]() == OfPtr[int](nil) got false, want true")
+	}
+	if FieldOf(0) == Null[int]() || Null[int]() == Undefined[int]() {
+		t.Errorf("fields in different states got equal, want not equal")
+	}
+}
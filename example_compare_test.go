@@ -0,0 +1,36 @@
+//go:build go1.21
+
+package optional_test
+
+import (
+	"fmt"
+	"slices"
+
+	"4d63.com/optional"
+)
+
+func Example_compareBy() {
+	type user struct {
+		Name string
+		Age  optional.Optional[int]
+	}
+
+	users := []user{
+		{"Alice", optional.Of(30)},
+		{"Bob", optional.Empty[int]()},
+		{"Carol", optional.Of(20)},
+	}
+
+	slices.SortFunc(users, optional.CompareBy(func(u user) optional.Optional[int] {
+		return u.Age
+	}, optional.EmptiesLast))
+
+	for _, u := range users {
+		fmt.Println(u.Name, u.Age)
+	}
+
+	// Output:
+	// Carol 20
+	// Alice 30
+	// Bob 0
+}
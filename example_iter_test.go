@@ -0,0 +1,55 @@
+//go:build go1.23
+
+package optional_test
+
+import (
+	"fmt"
+	"slices"
+
+	"4d63.com/optional"
+)
+
+func Example_all() {
+	values := []optional.Optional[int]{
+		optional.Empty[int](),
+		optional.Of(1000),
+	}
+
+	for _, v := range values {
+		for i := range v.All() {
+			fmt.Println(i)
+		}
+	}
+
+	// Output:
+	// 1000
+}
+
+func Example_values() {
+	values := []optional.Optional[int]{
+		optional.Empty[int](),
+		optional.Of(1000),
+		optional.Empty[int](),
+		optional.Of(1001),
+	}
+
+	for i := range optional.Values(slices.Values(values)) {
+		fmt.Println(i)
+	}
+
+	// Output:
+	// 1000
+	// 1001
+}
+
+func Example_find() {
+	values := []int{999, 1000, 1001}
+
+	even := optional.Find(slices.Values(values), func(i int) bool {
+		return i%2 == 0
+	})
+	fmt.Println(even.Get())
+
+	// Output:
+	// 1000 true
+}
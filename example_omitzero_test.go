@@ -0,0 +1,73 @@
+//go:build go1.24
+
+package optional_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"4d63.com/optional"
+)
+
+func Example_jsonMarshalOmitZero() {
+	s := struct {
+		Bool    optional.Optional[bool]      `json:"bool,omitzero"`
+		Byte    optional.Optional[byte]      `json:"byte,omitzero"`
+		Float32 optional.Optional[float32]   `json:"float32,omitzero"`
+		Float64 optional.Optional[float64]   `json:"float64,omitzero"`
+		Int16   optional.Optional[int16]     `json:"int16,omitzero"`
+		Int32   optional.Optional[int32]     `json:"int32,omitzero"`
+		Int64   optional.Optional[int64]     `json:"int64,omitzero"`
+		Int     optional.Optional[int]       `json:"int,omitzero"`
+		Rune    optional.Optional[rune]      `json:"rune,omitzero"`
+		String  optional.Optional[string]    `json:"string,omitzero"`
+		Time    optional.Optional[time.Time] `json:"time,omitzero"`
+		Uint16  optional.Optional[uint16]    `json:"uint16,omitzero"`
+		Uint32  optional.Optional[uint32]    `json:"uint32,omitzero"`
+		Uint64  optional.Optional[uint64]    `json:"uint64,omitzero"`
+		Uint    optional.Optional[uint]      `json:"uint,omitzero"`
+		Uintptr optional.Optional[uintptr]   `json:"uintptr,omitzero"`
+	}{
+		Bool:    optional.Empty[bool](),
+		Byte:    optional.Empty[byte](),
+		Float32: optional.Empty[float32](),
+		Float64: optional.Empty[float64](),
+		Int16:   optional.Empty[int16](),
+		Int32:   optional.Empty[int32](),
+		Int64:   optional.Empty[int64](),
+		Int:     optional.Empty[int](),
+		Rune:    optional.Empty[rune](),
+		String:  optional.Empty[string](),
+		Time:    optional.Empty[time.Time](),
+		Uint16:  optional.Empty[uint16](),
+		Uint32:  optional.Empty[uint32](),
+		Uint64:  optional.Empty[uint64](),
+		Uint:    optional.Empty[uint](),
+		Uintptr: optional.Empty[uintptr](),
+	}
+
+	output, _ := json.MarshalIndent(s, "", "  ")
+	fmt.Println(string(output))
+
+	// Output:
+	// {}
+}
+
+func Example_fieldJSONMarshalOmitZero() {
+	s := struct {
+		Name  optional.Field[string] `json:"name,omitzero"`
+		Email optional.Field[string] `json:"email,omitzero"`
+		Age   optional.Field[int]    `json:"age,omitzero"`
+	}{
+		Name:  optional.FieldOf("Jane"),
+		Email: optional.Null[string](),
+		Age:   optional.Undefined[int](),
+	}
+
+	output, _ := json.Marshal(s)
+	fmt.Println(string(output))
+
+	// Output:
+	// {"name":"Jane","email":null}
+}
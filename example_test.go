@@ -3,7 +3,9 @@ package optional_test
 import (
 	"encoding/json"
 	"encoding/xml"
+	"flag"
 	"fmt"
+	"strconv"
 	"time"
 
 	"4d63.com/optional"
@@ -111,48 +113,170 @@ func Example_elseFunc() {
 	// 1001
 }
 
-func Example_jsonMarshalOmitEmpty() {
-	s := struct {
-		Bool    optional.Optional[bool]      `json:"bool,omitempty"`
-		Byte    optional.Optional[byte]      `json:"byte,omitempty"`
-		Float32 optional.Optional[float32]   `json:"float32,omitempty"`
-		Float64 optional.Optional[float64]   `json:"float64,omitempty"`
-		Int16   optional.Optional[int16]     `json:"int16,omitempty"`
-		Int32   optional.Optional[int32]     `json:"int32,omitempty"`
-		Int64   optional.Optional[int64]     `json:"int64,omitempty"`
-		Int     optional.Optional[int]       `json:"int,omitempty"`
-		Rune    optional.Optional[rune]      `json:"rune,omitempty"`
-		String  optional.Optional[string]    `json:"string,omitempty"`
-		Time    optional.Optional[time.Time] `json:"time,omitempty"`
-		Uint16  optional.Optional[uint16]    `json:"uint16,omitempty"`
-		Uint32  optional.Optional[uint32]    `json:"uint32,omitempty"`
-		Uint64  optional.Optional[uint64]    `json:"uint64,omitempty"`
-		Uint    optional.Optional[uint]      `json:"uint,omitempty"`
-		Uintptr optional.Optional[uintptr]   `json:"uintptr,omitempty"`
-	}{
-		Bool:    optional.Empty[bool](),
-		Byte:    optional.Empty[byte](),
-		Float32: optional.Empty[float32](),
-		Float64: optional.Empty[float64](),
-		Int16:   optional.Empty[int16](),
-		Int32:   optional.Empty[int32](),
-		Int64:   optional.Empty[int64](),
-		Int:     optional.Empty[int](),
-		Rune:    optional.Empty[rune](),
-		String:  optional.Empty[string](),
-		Time:    optional.Empty[time.Time](),
-		Uint16:  optional.Empty[uint16](),
-		Uint32:  optional.Empty[uint32](),
-		Uint64:  optional.Empty[uint64](),
-		Uint:    optional.Empty[uint](),
-		Uintptr: optional.Empty[uintptr](),
+func Example_map() {
+	values := []optional.Optional[int]{
+		optional.Empty[int](),
+		optional.Of(1000),
 	}
 
-	output, _ := json.MarshalIndent(s, "", "  ")
-	fmt.Println(string(output))
+	for _, v := range values {
+		s := optional.Map(v, func(i int) string {
+			return fmt.Sprintf("#%d", i)
+		})
+		fmt.Println(s.Else("none"))
+	}
+
+	// Output:
+	// none
+	// #1000
+}
+
+func Example_zip() {
+	first := optional.Of("Jane")
+	last := optional.Of("Doe")
+
+	name := optional.Map(optional.Zip(first, last), func(p optional.Pair[string, string]) string {
+		return p.First + " " + p.Second
+	})
+	fmt.Println(name.Else("anonymous"))
+
+	// Output:
+	// Jane Doe
+}
+
+func Example_or() {
+	flag := optional.Empty[int]()
+	env := optional.Of(8080)
+
+	fmt.Println(flag.Or(env).Else(80))
+
+	// Output:
+	// 8080
+}
+
+func Example_result() {
+	for _, s := range []string{"1000", "x"} {
+		r := optional.ResultOf(strconv.Atoi(s))
+		if i, ok := r.Get(); ok {
+			fmt.Println(i)
+		} else {
+			fmt.Println(r.Err())
+		}
+	}
+
+	// Output:
+	// 1000
+	// strconv.Atoi: parsing "x": invalid syntax
+}
+
+func Example_ptr() {
+	values := []optional.Optional[int]{
+		optional.Empty[int](),
+		optional.Of(1000),
+	}
+
+	for _, v := range values {
+		if p := v.Ptr(); p != nil {
+			fmt.Println(*p)
+		} else {
+			fmt.Println("nil")
+		}
+	}
+
+	// Output:
+	// nil
+	// 1000
+}
+
+func Example_mapKey() {
+	type key struct {
+		ID   int
+		Name optional.Optional[string]
+	}
+
+	seen := map[key]bool{}
+	seen[key{1, optional.Empty[string]()}] = true
+
+	fmt.Println(seen[key{1, optional.Empty[string]()}])
+	fmt.Println(seen[key{1, optional.Of("")}])
+
+	// Output:
+	// true
+	// false
+}
+
+func Example_format() {
+	values := []optional.Optional[int]{
+		optional.Empty[int](),
+		optional.Of(0),
+	}
+
+	for _, v := range values {
+		fmt.Printf("%v %+v %#v\n", v, v, v)
+	}
 
 	// Output:
-	// {}
+	// 0 None optional.Empty[int]()
+	// 0 Some(0) optional.Of[int](0)
+}
+
+func Example_flag() {
+	fs := flag.NewFlagSet("example", flag.ExitOnError)
+
+	var name optional.Optional[string]
+	var port optional.Optional[int]
+	optional.FlagVar(fs, &name, "name", "the name")
+	optional.FlagVar(fs, &port, "port", "the port")
+
+	_ = fs.Parse([]string{"-port=8080"})
+
+	fmt.Println(name.IsPresent(), port.IsPresent())
+	fmt.Println(port.Else(80))
+
+	// Output:
+	// false true
+	// 8080
+}
+
+func Example_merge() {
+	type config struct {
+		Addr optional.Optional[string]
+		Port optional.Optional[int]
+	}
+
+	defaults := config{Addr: optional.Of("localhost"), Port: optional.Of(80)}
+	file := config{Port: optional.Of(8080)}
+	flags := config{}
+
+	c, sources := optional.MergeSources(defaults, file, flags)
+
+	fmt.Println(c.Addr, sources["Addr"])
+	fmt.Println(c.Port, sources["Port"])
+
+	// Output:
+	// localhost 0
+	// 8080 1
+}
+
+func Example_applyDefaults() {
+	type config struct {
+		Addr    optional.Optional[string]        `json:"addr" default:"localhost"`
+		Timeout optional.Optional[time.Duration] `json:"timeout" default:"30s"`
+	}
+
+	c := config{}
+	_ = json.Unmarshal([]byte(`{"addr":"example.com"}`), &c)
+
+	err := optional.ApplyDefaults(&c)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(c.Addr, c.Timeout)
+
+	// Output:
+	// example.com 30s
 }
 
 func Example_jsonMarshalEmpty() {
@@ -197,22 +321,22 @@ func Example_jsonMarshalEmpty() {
 
 	// Output:
 	// {
-	//   "bool": false,
-	//   "byte": 0,
-	//   "float32": 0,
-	//   "float64": 0,
-	//   "int16": 0,
-	//   "int32": 0,
-	//   "int64": 0,
-	//   "int": 0,
-	//   "rune": 0,
-	//   "string": "",
-	//   "time": "0001-01-01T00:00:00Z",
-	//   "uint16": 0,
-	//   "uint32": 0,
-	//   "uint64": 0,
-	//   "uint": 0,
-	//   "uintptr": 0
+	//   "bool": null,
+	//   "byte": null,
+	//   "float32": null,
+	//   "float64": null,
+	//   "int16": null,
+	//   "int32": null,
+	//   "int64": null,
+	//   "int": null,
+	//   "rune": null,
+	//   "string": null,
+	//   "time": null,
+	//   "uint16": null,
+	//   "uint32": null,
+	//   "uint64": null,
+	//   "uint": null,
+	//   "uintptr": null
 	// }
 }
 
@@ -337,6 +461,22 @@ func Example_jsonUnmarshalEmpty() {
 	// Uintptr: false
 }
 
+func Example_jsonUnmarshalNull() {
+	s := struct {
+		Int    optional.Optional[int]    `json:"int"`
+		String optional.Optional[string] `json:"string"`
+	}{}
+
+	x := `{"int":null,"string":null}`
+	json.Unmarshal([]byte(x), &s)
+	fmt.Println("Int:", s.Int.IsPresent())
+	fmt.Println("String:", s.String.IsPresent())
+
+	// Output:
+	// Int: false
+	// String: false
+}
+
 func Example_jsonUnmarshalPresent() {
 	s := struct {
 		Bool    optional.Optional[bool]      `json:"bool"`
@@ -414,6 +554,25 @@ func Example_jsonUnmarshalPresent() {
 	// Uintptr: true 0
 }
 
+func Example_fieldJSONUnmarshal() {
+	s := struct {
+		Name  optional.Field[string] `json:"name"`
+		Email optional.Field[string] `json:"email"`
+		Age   optional.Field[int]    `json:"age"`
+	}{}
+
+	x := `{"name":"Jane","email":null}`
+	json.Unmarshal([]byte(x), &s)
+	fmt.Println("Name:", s.Name.IsDefined(), s.Name.IsNull(), s.Name.IsPresent())
+	fmt.Println("Email:", s.Email.IsDefined(), s.Email.IsNull(), s.Email.IsPresent())
+	fmt.Println("Age:", s.Age.IsDefined(), s.Age.IsNull(), s.Age.IsPresent())
+
+	// Output:
+	// Name: true false true
+	// Email: true true false
+	// Age: false false false
+}
+
 func Example_xmlMarshalOmitEmpty() {
 	s := struct {
 		XMLName xml.Name                     `xml:"s"`
@@ -500,6 +659,51 @@ func Example_xmlMarshalEmpty() {
 	output, _ := xml.MarshalIndent(s, "", "  ")
 	fmt.Println(string(output))
 
+	// Output:
+	// <s></s>
+}
+
+func Example_xmlMarshalEmptyOrZero() {
+	s := struct {
+		XMLName xml.Name                   `xml:"s"`
+		Bool    optional.OrZero[bool]      `xml:"bool"`
+		Byte    optional.OrZero[byte]      `xml:"byte"`
+		Float32 optional.OrZero[float32]   `xml:"float32"`
+		Float64 optional.OrZero[float64]   `xml:"float64"`
+		Int16   optional.OrZero[int16]     `xml:"int16"`
+		Int32   optional.OrZero[int32]     `xml:"int32"`
+		Int64   optional.OrZero[int64]     `xml:"int64"`
+		Int     optional.OrZero[int]       `xml:"int"`
+		Rune    optional.OrZero[rune]      `xml:"rune"`
+		String  optional.OrZero[string]    `xml:"string"`
+		Time    optional.OrZero[time.Time] `xml:"time"`
+		Uint16  optional.OrZero[uint16]    `xml:"uint16"`
+		Uint32  optional.OrZero[uint32]    `xml:"uint32"`
+		Uint64  optional.OrZero[uint64]    `xml:"uint64"`
+		Uint    optional.OrZero[uint]      `xml:"uint"`
+		Uintptr optional.OrZero[uintptr]   `xml:"uintptr"`
+	}{
+		Bool:    optional.OrZero[bool]{Optional: optional.Empty[bool]()},
+		Byte:    optional.OrZero[byte]{Optional: optional.Empty[byte]()},
+		Float32: optional.OrZero[float32]{Optional: optional.Empty[float32]()},
+		Float64: optional.OrZero[float64]{Optional: optional.Empty[float64]()},
+		Int16:   optional.OrZero[int16]{Optional: optional.Empty[int16]()},
+		Int32:   optional.OrZero[int32]{Optional: optional.Empty[int32]()},
+		Int64:   optional.OrZero[int64]{Optional: optional.Empty[int64]()},
+		Int:     optional.OrZero[int]{Optional: optional.Empty[int]()},
+		Rune:    optional.OrZero[rune]{Optional: optional.Empty[rune]()},
+		String:  optional.OrZero[string]{Optional: optional.Empty[string]()},
+		Time:    optional.OrZero[time.Time]{Optional: optional.Empty[time.Time]()},
+		Uint16:  optional.OrZero[uint16]{Optional: optional.Empty[uint16]()},
+		Uint32:  optional.OrZero[uint32]{Optional: optional.Empty[uint32]()},
+		Uint64:  optional.OrZero[uint64]{Optional: optional.Empty[uint64]()},
+		Uint:    optional.OrZero[uint]{Optional: optional.Empty[uint]()},
+		Uintptr: optional.OrZero[uintptr]{Optional: optional.Empty[uintptr]()},
+	}
+
+	output, _ := xml.MarshalIndent(s, "", "  ")
+	fmt.Println(string(output))
+
 	// Output:
 	// <s>
 	//   <bool>false</bool>
@@ -721,3 +925,256 @@ func Example_xmlUnmarshalPresent() {
 	// Uint: true 0
 	// Uintptr: true 0
 }
+
+func Example_xmlAttrMarshalOmitEmpty() {
+	s := struct {
+		XMLName xml.Name                     `xml:"s"`
+		Bool    optional.Optional[bool]      `xml:"bool,attr,omitempty"`
+		Byte    optional.Optional[byte]      `xml:"byte,attr,omitempty"`
+		Float32 optional.Optional[float32]   `xml:"float32,attr,omitempty"`
+		Float64 optional.Optional[float64]   `xml:"float64,attr,omitempty"`
+		Int16   optional.Optional[int16]     `xml:"int16,attr,omitempty"`
+		Int32   optional.Optional[int32]     `xml:"int32,attr,omitempty"`
+		Int64   optional.Optional[int64]     `xml:"int64,attr,omitempty"`
+		Int     optional.Optional[int]       `xml:"int,attr,omitempty"`
+		Rune    optional.Optional[rune]      `xml:"rune,attr,omitempty"`
+		String  optional.Optional[string]    `xml:"string,attr,omitempty"`
+		Time    optional.Optional[time.Time] `xml:"time,attr,omitempty"`
+		Uint16  optional.Optional[uint16]    `xml:"uint16,attr,omitempty"`
+		Uint32  optional.Optional[uint32]    `xml:"uint32,attr,omitempty"`
+		Uint64  optional.Optional[uint64]    `xml:"uint64,attr,omitempty"`
+		Uint    optional.Optional[uint]      `xml:"uint,attr,omitempty"`
+		Uintptr optional.Optional[uintptr]   `xml:"uintptr,attr,omitempty"`
+	}{
+		Bool:    optional.Empty[bool](),
+		Byte:    optional.Empty[byte](),
+		Float32: optional.Empty[float32](),
+		Float64: optional.Empty[float64](),
+		Int16:   optional.Empty[int16](),
+		Int32:   optional.Empty[int32](),
+		Int64:   optional.Empty[int64](),
+		Int:     optional.Empty[int](),
+		Rune:    optional.Empty[rune](),
+		String:  optional.Empty[string](),
+		Time:    optional.Empty[time.Time](),
+		Uint16:  optional.Empty[uint16](),
+		Uint32:  optional.Empty[uint32](),
+		Uint64:  optional.Empty[uint64](),
+		Uint:    optional.Empty[uint](),
+		Uintptr: optional.Empty[uintptr](),
+	}
+
+	output, _ := xml.MarshalIndent(s, "", "  ")
+	fmt.Println(string(output))
+
+	// Output:
+	// <s></s>
+}
+
+func Example_xmlAttrMarshalEmpty() {
+	s := struct {
+		XMLName xml.Name                     `xml:"s"`
+		Bool    optional.Optional[bool]      `xml:"bool,attr"`
+		Byte    optional.Optional[byte]      `xml:"byte,attr"`
+		Float32 optional.Optional[float32]   `xml:"float32,attr"`
+		Float64 optional.Optional[float64]   `xml:"float64,attr"`
+		Int16   optional.Optional[int16]     `xml:"int16,attr"`
+		Int32   optional.Optional[int32]     `xml:"int32,attr"`
+		Int64   optional.Optional[int64]     `xml:"int64,attr"`
+		Int     optional.Optional[int]       `xml:"int,attr"`
+		Rune    optional.Optional[rune]      `xml:"rune,attr"`
+		String  optional.Optional[string]    `xml:"string,attr"`
+		Time    optional.Optional[time.Time] `xml:"time,attr"`
+		Uint16  optional.Optional[uint16]    `xml:"uint16,attr"`
+		Uint32  optional.Optional[uint32]    `xml:"uint32,attr"`
+		Uint64  optional.Optional[uint64]    `xml:"uint64,attr"`
+		Uint    optional.Optional[uint]      `xml:"uint,attr"`
+		Uintptr optional.Optional[uintptr]   `xml:"uintptr,attr"`
+	}{
+		Bool:    optional.Empty[bool](),
+		Byte:    optional.Empty[byte](),
+		Float32: optional.Empty[float32](),
+		Float64: optional.Empty[float64](),
+		Int16:   optional.Empty[int16](),
+		Int32:   optional.Empty[int32](),
+		Int64:   optional.Empty[int64](),
+		Int:     optional.Empty[int](),
+		Rune:    optional.Empty[rune](),
+		String:  optional.Empty[string](),
+		Time:    optional.Empty[time.Time](),
+		Uint16:  optional.Empty[uint16](),
+		Uint32:  optional.Empty[uint32](),
+		Uint64:  optional.Empty[uint64](),
+		Uint:    optional.Empty[uint](),
+		Uintptr: optional.Empty[uintptr](),
+	}
+
+	output, _ := xml.MarshalIndent(s, "", "  ")
+	fmt.Println(string(output))
+
+	// Output:
+	// <s></s>
+}
+
+func Example_xmlAttrMarshalPresent() {
+	s := struct {
+		XMLName xml.Name                     `xml:"s"`
+		Bool    optional.Optional[bool]      `xml:"bool,attr"`
+		Byte    optional.Optional[byte]      `xml:"byte,attr"`
+		Float32 optional.Optional[float32]   `xml:"float32,attr"`
+		Float64 optional.Optional[float64]   `xml:"float64,attr"`
+		Int16   optional.Optional[int16]     `xml:"int16,attr"`
+		Int32   optional.Optional[int32]     `xml:"int32,attr"`
+		Int64   optional.Optional[int64]     `xml:"int64,attr"`
+		Int     optional.Optional[int]       `xml:"int,attr"`
+		Rune    optional.Optional[rune]      `xml:"rune,attr"`
+		String  optional.Optional[string]    `xml:"string,attr"`
+		Time    optional.Optional[time.Time] `xml:"time,attr"`
+		Uint16  optional.Optional[uint16]    `xml:"uint16,attr"`
+		Uint32  optional.Optional[uint32]    `xml:"uint32,attr"`
+		Uint64  optional.Optional[uint64]    `xml:"uint64,attr"`
+		Uint    optional.Optional[uint]      `xml:"uint,attr"`
+		Uintptr optional.Optional[uintptr]   `xml:"uintptr,attr"`
+	}{
+		Bool:    optional.Of(true),
+		Byte:    optional.Of[byte](1),
+		Float32: optional.Of[float32](2.1),
+		Float64: optional.Of(2.2),
+		Int16:   optional.Of[int16](3),
+		Int32:   optional.Of[int32](4),
+		Int64:   optional.Of[int64](5),
+		Int:     optional.Of(6),
+		Rune:    optional.Of[rune](7),
+		String:  optional.Of("string"),
+		Time:    optional.Of(time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)),
+		Uint16:  optional.Of[uint16](8),
+		Uint32:  optional.Of[uint32](9),
+		Uint64:  optional.Of[uint64](10),
+		Uint:    optional.Of[uint](11),
+		Uintptr: optional.Of[uintptr](12),
+	}
+
+	output, _ := xml.MarshalIndent(s, "", "  ")
+	fmt.Println(string(output))
+
+	// Output:
+	// <s bool="true" byte="1" float32="2.1" float64="2.2" int16="3" int32="4" int64="5" int="6" rune="7" string="string" time="2006-01-02T15:04:05Z" uint16="8" uint32="9" uint64="10" uint="11" uintptr="12"></s>
+}
+
+func Example_xmlAttrUnmarshalEmpty() {
+	s := struct {
+		XMLName xml.Name                     `xml:"s"`
+		Bool    optional.Optional[bool]      `xml:"bool,attr"`
+		Byte    optional.Optional[byte]      `xml:"byte,attr"`
+		Float32 optional.Optional[float32]   `xml:"float32,attr"`
+		Float64 optional.Optional[float64]   `xml:"float64,attr"`
+		Int16   optional.Optional[int16]     `xml:"int16,attr"`
+		Int32   optional.Optional[int32]     `xml:"int32,attr"`
+		Int64   optional.Optional[int64]     `xml:"int64,attr"`
+		Int     optional.Optional[int]       `xml:"int,attr"`
+		Rune    optional.Optional[rune]      `xml:"rune,attr"`
+		String  optional.Optional[string]    `xml:"string,attr"`
+		Time    optional.Optional[time.Time] `xml:"time,attr"`
+		Uint16  optional.Optional[uint16]    `xml:"uint16,attr"`
+		Uint32  optional.Optional[uint32]    `xml:"uint32,attr"`
+		Uint64  optional.Optional[uint64]    `xml:"uint64,attr"`
+		Uint    optional.Optional[uint]      `xml:"uint,attr"`
+		Uintptr optional.Optional[uintptr]   `xml:"uintptr,attr"`
+	}{}
+
+	x := `<s></s>`
+	xml.Unmarshal([]byte(x), &s)
+	fmt.Println("Bool:", s.Bool.IsPresent())
+	fmt.Println("Byte:", s.Byte.IsPresent())
+	fmt.Println("Float32:", s.Float32.IsPresent())
+	fmt.Println("Float64:", s.Float64.IsPresent())
+	fmt.Println("Int16:", s.Int16.IsPresent())
+	fmt.Println("Int32:", s.Int32.IsPresent())
+	fmt.Println("Int64:", s.Int64.IsPresent())
+	fmt.Println("Int:", s.Int.IsPresent())
+	fmt.Println("Rune:", s.Rune.IsPresent())
+	fmt.Println("String:", s.String.IsPresent())
+	fmt.Println("Time:", s.Time.IsPresent())
+	fmt.Println("Uint16:", s.Uint16.IsPresent())
+	fmt.Println("Uint32:", s.Uint32.IsPresent())
+	fmt.Println("Uint64:", s.Uint64.IsPresent())
+	fmt.Println("Uint:", s.Uint.IsPresent())
+	fmt.Println("Uintptr:", s.Uintptr.IsPresent())
+
+	// Output:
+	// Bool: false
+	// Byte: false
+	// Float32: false
+	// Float64: false
+	// Int16: false
+	// Int32: false
+	// Int64: false
+	// Int: false
+	// Rune: false
+	// String: false
+	// Time: false
+	// Uint16: false
+	// Uint32: false
+	// Uint64: false
+	// Uint: false
+	// Uintptr: false
+}
+
+func Example_xmlAttrUnmarshalPresent() {
+	s := struct {
+		XMLName xml.Name                     `xml:"s"`
+		Bool    optional.Optional[bool]      `xml:"bool,attr"`
+		Byte    optional.Optional[byte]      `xml:"byte,attr"`
+		Float32 optional.Optional[float32]   `xml:"float32,attr"`
+		Float64 optional.Optional[float64]   `xml:"float64,attr"`
+		Int16   optional.Optional[int16]     `xml:"int16,attr"`
+		Int32   optional.Optional[int32]     `xml:"int32,attr"`
+		Int64   optional.Optional[int64]     `xml:"int64,attr"`
+		Int     optional.Optional[int]       `xml:"int,attr"`
+		Rune    optional.Optional[rune]      `xml:"rune,attr"`
+		String  optional.Optional[string]    `xml:"string,attr"`
+		Time    optional.Optional[time.Time] `xml:"time,attr"`
+		Uint16  optional.Optional[uint16]    `xml:"uint16,attr"`
+		Uint32  optional.Optional[uint32]    `xml:"uint32,attr"`
+		Uint64  optional.Optional[uint64]    `xml:"uint64,attr"`
+		Uint    optional.Optional[uint]      `xml:"uint,attr"`
+		Uintptr optional.Optional[uintptr]   `xml:"uintptr,attr"`
+	}{}
+
+	x := `<s bool="false" byte="0" float32="0" float64="0" int16="0" int32="0" int64="0" int="0" rune="0" string="string" time="0001-01-01T00:00:00Z" uint16="0" uint32="0" uint64="0" uint="0" uintptr="0"></s>`
+	xml.Unmarshal([]byte(x), &s)
+	fmt.Println("Bool:", s.Bool.IsPresent(), s.Bool)
+	fmt.Println("Byte:", s.Byte.IsPresent(), s.Byte)
+	fmt.Println("Float32:", s.Float32.IsPresent(), s.Float32)
+	fmt.Println("Float64:", s.Float64.IsPresent(), s.Float64)
+	fmt.Println("Int16:", s.Int16.IsPresent(), s.Int16)
+	fmt.Println("Int32:", s.Int32.IsPresent(), s.Int32)
+	fmt.Println("Int64:", s.Int64.IsPresent(), s.Int64)
+	fmt.Println("Int:", s.Int.IsPresent(), s.Int)
+	fmt.Println("Rune:", s.Rune.IsPresent(), s.Rune)
+	fmt.Println("String:", s.String.IsPresent(), s.String)
+	fmt.Println("Time:", s.Time.IsPresent(), s.Time)
+	fmt.Println("Uint16:", s.Uint16.IsPresent(), s.Uint16)
+	fmt.Println("Uint32:", s.Uint32.IsPresent(), s.Uint32)
+	fmt.Println("Uint64:", s.Uint64.IsPresent(), s.Uint64)
+	fmt.Println("Uint:", s.Uint.IsPresent(), s.Uint)
+	fmt.Println("Uintptr:", s.Uintptr.IsPresent(), s.Uintptr)
+
+	// Output:
+	// Bool: true false
+	// Byte: true 0
+	// Float32: true 0
+	// Float64: true 0
+	// Int16: true 0
+	// Int32: true 0
+	// Int64: true 0
+	// Int: true 0
+	// Rune: true 0
+	// String: true string
+	// Time: true 0001-01-01 00:00:00 +0000 UTC
+	// Uint16: true 0
+	// Uint32: true 0
+	// Uint64: true 0
+	// Uint: true 0
+	// Uintptr: true 0
+}
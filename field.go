@@ -0,0 +1,128 @@
+package optional
+
+import (
+	"encoding/json"
+)
+
+// Field is a tri-state value that distinguishes a value that is undefined,
+// explicitly null, or set to a value. It is intended for fields in JSON
+// documents where the absence of a field and a field set to null have
+// different meanings, such as JSON merge-patch request bodies.
+//
+// The zero value of a Field is undefined. A Field that is not present in the
+// JSON being unmarshaled is left undefined. An undefined Field is omitted when
+// marshaled if the field has the omitzero option, otherwise it is marshaled as
+// null.
+type Field[T any] struct {
+	value   Optional[T]
+	defined bool
+}
+
+// FieldOf wraps the value in a field.
+func FieldOf[T any](value T) Field[T] {
+	return Field[T]{value: Of(value), defined: true}
+}
+
+// FieldOfOptional returns a field set to the value wrapped by the optional, or
+// a null field if the optional is empty.
+func FieldOfOptional[T any](o Optional[T]) Field[T] {
+	return Field[T]{value: o, defined: true}
+}
+
+// Null returns a field that is explicitly null.
+func Null[T any]() Field[T] {
+	return Field[T]{value: Empty[T](), defined: true}
+}
+
+// Undefined returns a field that is undefined.
+func Undefined[T any]() Field[T] {
+	return Field[T]{}
+}
+
+// IsDefined returns true if the field is null or set to a value.
+func (f Field[T]) IsDefined() bool {
+	return f.defined
+}
+
+// IsZero returns true if the field is undefined. It is used by encoding/json
+// to omit undefined fields from fields with the omitzero option.
+func (f Field[T]) IsZero() bool {
+	return !f.defined
+}
+
+// IsNull returns true if the field is explicitly null.
+func (f Field[T]) IsNull() bool {
+	return f.IsDefined() && !f.value.IsPresent()
+}
+
+// IsPresent returns true if the field is set to a value.
+func (f Field[T]) IsPresent() bool {
+	return f.Optional().IsPresent()
+}
+
+// Optional returns the value of the field as an optional, that is empty if the
+// field is undefined or null.
+func (f Field[T]) Optional() Optional[T] {
+	return f.value
+}
+
+// Get returns the value of the field, and an ok signal for whether the field
+// is set to a value.
+func (f Field[T]) Get() (value T, ok bool) {
+	return f.Optional().Get()
+}
+
+// If calls the function if the field is set to a value.
+func (f Field[T]) If(fn func(value T)) {
+	f.Optional().If(fn)
+}
+
+// ElseFunc returns the value of the field, or the value returned by the
+// function if the field is undefined or null.
+func (f Field[T]) ElseFunc(fn func() T) (value T) {
+	return f.Optional().ElseFunc(fn)
+}
+
+// Else returns the value of the field, or the value passed in if the field is
+// undefined or null.
+func (f Field[T]) Else(elseValue T) (value T) {
+	return f.Optional().Else(elseValue)
+}
+
+// ElseZero returns the value of the field, or the zero value of the type
+// wrapped if the field is undefined or null.
+func (f Field[T]) ElseZero() (value T) {
+	return f.Optional().ElseZero()
+}
+
+// String returns the string representation of the value of the field, or the
+// string representation of the zero value of the type wrapped if the field is
+// undefined or null.
+func (f Field[T]) String() string {
+	return f.Optional().String()
+}
+
+// MarshalJSON marshals the value of the field to JSON. If the field is
+// undefined or null, null is marshaled.
+func (f Field[T]) MarshalJSON() (data []byte, err error) {
+	if !f.IsPresent() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(f.ElseZero())
+}
+
+// UnmarshalJSON unmarshals the JSON into the value of the field. If the JSON
+// is null the field is null.
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*f = Null[T]()
+		return nil
+	}
+	var v T
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+	*f = FieldOf(v)
+	return nil
+}
@@ -0,0 +1,135 @@
+package optional
+
+import "testing"
+
+func TestFieldState(t *testing.T) {
+	tests := []struct {
+		Field             Field[string]
+		ExpectedIsDefined bool
+		ExpectedIsNull    bool
+		ExpectedIsPresent bool
+	}{
+		{Undefined[string](), false, false, false},
+		{Null[string](), true, true, false},
+		{FieldOf(""), true, false, true},
+		{FieldOf("string"), true, false, true},
+		{FieldOfOptional(Empty[string]()), true, true, false},
+		{FieldOfOptional(Of("string")), true, false, true},
+	}
+
+	for _, test := range tests {
+		isDefined := test.Field.IsDefined()
+		isNull := test.Field.IsNull()
+		isPresent := test.Field.IsPresent()
+
+		if isDefined != test.ExpectedIsDefined || isNull != test.ExpectedIsNull || isPresent != test.ExpectedIsPresent {
+			t.Errorf("%#v IsDefined, IsNull, IsPresent got %#v, %#v, %#v, want %#v, %#v, %#v", test.Field, isDefined, isNull, isPresent, test.ExpectedIsDefined, test.ExpectedIsNull, test.ExpectedIsPresent)
+		}
+	}
+}
+
+func TestFieldGet(t *testing.T) {
+	tests := []struct {
+		Field         Field[string]
+		ExpectedValue string
+		ExpectedOk    bool
+	}{
+		{Undefined[string](), "", false},
+		{Null[string](), "", false},
+		{FieldOf(""), "", true},
+		{FieldOf("string"), "string", true},
+	}
+
+	for _, test := range tests {
+		value, ok := test.Field.Get()
+
+		if value != test.ExpectedValue || ok != test.ExpectedOk {
+			t.Errorf("%#v Get got %#v, %#v, want %#v, %#v", test.Field, value, ok, test.ExpectedValue, test.ExpectedOk)
+		}
+	}
+}
+
+func TestFieldElse(t *testing.T) {
+	const orElse = "orelse"
+	tests := []struct {
+		Field          Field[string]
+		ExpectedResult string
+	}{
+		{Undefined[string](), orElse},
+		{Null[string](), orElse},
+		{FieldOf(""), ""},
+		{FieldOf("string"), "string"},
+	}
+
+	for _, test := range tests {
+		result := test.Field.Else(orElse)
+
+		if result != test.ExpectedResult {
+			t.Errorf("%#v Else(%#v) got %#v, want %#v", test.Field, orElse, result, test.ExpectedResult)
+		}
+	}
+}
+
+func TestFieldOptional(t *testing.T) {
+	tests := []struct {
+		Field             Field[string]
+		ExpectedValue     string
+		ExpectedIsPresent bool
+	}{
+		{Undefined[string](), "", false},
+		{Null[string](), "", false},
+		{FieldOf(""), "", true},
+		{FieldOf("string"), "string", true},
+	}
+
+	for _, test := range tests {
+		value, ok := test.Field.Optional().Get()
+
+		if value != test.ExpectedValue || ok != test.ExpectedIsPresent {
+			t.Errorf("%#v Optional got %#v, %#v, want %#v, %#v", test.Field, value, ok, test.ExpectedValue, test.ExpectedIsPresent)
+		}
+	}
+}
+
+func TestFieldMarshalJSON(t *testing.T) {
+	tests := []struct {
+		Field           Field[int]
+		ExpectedMarshal string
+	}{
+		{Undefined[int](), `null`},
+		{Null[int](), `null`},
+		{FieldOf(0), `0`},
+		{FieldOf(1), `1`},
+	}
+
+	for _, test := range tests {
+		data, err := test.Field.MarshalJSON()
+
+		if err != nil || string(data) != test.ExpectedMarshal {
+			t.Errorf("%#v MarshalJSON got %#v, %v, want %#v", test.Field, string(data), err, test.ExpectedMarshal)
+		}
+	}
+}
+
+func TestFieldUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		JSON           string
+		ExpectedIsNull bool
+		ExpectedValue  int
+		ExpectedOk     bool
+	}{
+		{`null`, true, 0, false},
+		{`0`, false, 0, true},
+		{`1`, false, 1, true},
+	}
+
+	for _, test := range tests {
+		f := Undefined[int]()
+		err := f.UnmarshalJSON([]byte(test.JSON))
+		value, ok := f.Get()
+
+		if err != nil || !f.IsDefined() || f.IsNull() != test.ExpectedIsNull || value != test.ExpectedValue || ok != test.ExpectedOk {
+			t.Errorf("%#v UnmarshalJSON got %#v, %v, want %#v", test.JSON, f, err, test)
+		}
+	}
+}
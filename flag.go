@@ -0,0 +1,63 @@
+package optional
+
+import (
+	"flag"
+	"reflect"
+
+	"4d63.com/optional/internal/textenc"
+)
+
+// FlagValue returns a flag.Value that sets the optional when the flag is set.
+// The optional is left as it is if the flag is not set, so an empty optional
+// reports whether the flag was set with IsPresent. The flag's text is parsed
+// into the type wrapped with its UnmarshalText method if it implements
+// encoding.TextUnmarshaler, otherwise values of the basic kinds are parsed
+// using strconv. A flag set to the empty string sets the optional to the
+// parsed empty string, not empty. Flags of optionals wrapping a bool can be
+// set without a value, like bool flags.
+func FlagValue[T any](o *Optional[T]) flag.Value {
+	return &flagValue[T]{o: o}
+}
+
+// FlagVar defines a flag with the name and usage in the flag set, that sets
+// the optional when the flag is set. See FlagValue.
+func FlagVar[T any](fs *flag.FlagSet, o *Optional[T], name string, usage string) {
+	fs.Var(FlagValue(o), name, usage)
+}
+
+type flagValue[T any] struct {
+	o *Optional[T]
+}
+
+// String returns the text form of the value wrapped by the optional, or the
+// empty string if there is no value wrapped.
+func (f *flagValue[T]) String() string {
+	if f.o == nil {
+		return ""
+	}
+	v, ok := f.o.Get()
+	if !ok {
+		return ""
+	}
+	text, err := textenc.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(text)
+}
+
+// Set parses the text into a value wrapped by the optional.
+func (f *flagValue[T]) Set(s string) error {
+	return f.o.setText(s)
+}
+
+// Get returns the optional, and implements flag.Getter.
+func (f *flagValue[T]) Get() any {
+	return *f.o
+}
+
+// IsBoolFlag returns true if the type wrapped is a bool, so that the flag
+// package allows the flag to be set without a value.
+func (f *flagValue[T]) IsBoolFlag() bool {
+	return reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.Bool
+}
@@ -0,0 +1,95 @@
+package optional
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFlagVar(t *testing.T) {
+	tests := []struct {
+		Args            []string
+		ExpectedName    Optional[string]
+		ExpectedCount   Optional[int]
+		ExpectedVerbose Optional[bool]
+		ExpectedTimeout Optional[time.Duration]
+		ExpectedLevel   Optional[level]
+		ExpectedErr     bool
+	}{
+		{[]string{}, Empty[string](), Empty[int](), Empty[bool](), Empty[time.Duration](), Empty[level](), false},
+		{[]string{"-name=a"}, Of("a"), Empty[int](), Empty[bool](), Empty[time.Duration](), Empty[level](), false},
+		{[]string{"-name="}, Of(""), Empty[int](), Empty[bool](), Empty[time.Duration](), Empty[level](), false},
+		{[]string{"-count", "0"}, Empty[string](), Of(0), Empty[bool](), Empty[time.Duration](), Empty[level](), false},
+		{[]string{"-verbose"}, Empty[string](), Empty[int](), Of(true), Empty[time.Duration](), Empty[level](), false},
+		{[]string{"-verbose=false"}, Empty[string](), Empty[int](), Of(false), Empty[time.Duration](), Empty[level](), false},
+		{[]string{"-timeout=30s"}, Empty[string](), Empty[int](), Empty[bool](), Of(30 * time.Second), Empty[level](), false},
+		{[]string{"-level=2"}, Empty[string](), Empty[int](), Empty[bool](), Empty[time.Duration](), Of(level(2)), false},
+		{[]string{"-count=x"}, Empty[string](), Empty[int](), Empty[bool](), Empty[time.Duration](), Empty[level](), true},
+	}
+
+	for _, test := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		var name Optional[string]
+		var count Optional[int]
+		var verbose Optional[bool]
+		var timeout Optional[time.Duration]
+		var lvl Optional[level]
+		FlagVar(fs, &name, "name", "")
+		FlagVar(fs, &count, "count", "")
+		FlagVar(fs, &verbose, "verbose", "")
+		FlagVar(fs, &timeout, "timeout", "")
+		FlagVar(fs, &lvl, "level", "")
+
+		err := fs.Parse(test.Args)
+
+		if (err != nil) != test.ExpectedErr {
+			t.Errorf("%q Parse got err %v, want err %v", test.Args, err, test.ExpectedErr)
+		}
+		if name != test.ExpectedName || count != test.ExpectedCount || verbose != test.ExpectedVerbose || timeout != test.ExpectedTimeout || lvl != test.ExpectedLevel {
+			t.Errorf("%q Parse got %#v, %#v, %#v, %#v, %#v, want %#v, %#v, %#v, %#v, %#v", test.Args, name, count, verbose, timeout, lvl, test.ExpectedName, test.ExpectedCount, test.ExpectedVerbose, test.ExpectedTimeout, test.ExpectedLevel)
+		}
+	}
+}
+
+func TestFlagValue(t *testing.T) {
+	o := Of(30 * time.Second)
+	v := FlagValue(&o)
+
+	if s := v.String(); s != "30s" {
+		t.Errorf("String got %q, want %q", s, "30s")
+	}
+	if g := v.(flag.Getter).Get(); g != o {
+		t.Errorf("Get got %#v, want %#v", g, o)
+	}
+
+	e := Empty[int]()
+	if s := FlagValue(&e).String(); s != "" {
+		t.Errorf("String got %q, want %q", s, "")
+	}
+}
+
+func TestFlagDefaults(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var name Optional[string]
+	FlagVar(fs, &name, "name", "the `name` to use")
+
+	b := strings.Builder{}
+	fs.SetOutput(&b)
+	fs.PrintDefaults()
+
+	want := "  -name name\n    \tthe name to use\n"
+	if b.String() != want {
+		t.Errorf("PrintDefaults got %q, want %q", b.String(), want)
+	}
+}
+
+func TestOptionalIsNotFlagValue(t *testing.T) {
+	var o any = &Optional[bool]{}
+
+	if _, ok := o.(flag.Value); ok {
+		t.Errorf("*Optional[bool] implements flag.Value, want only FlagValue to return one so that bool flags have IsBoolFlag")
+	}
+}
@@ -0,0 +1,64 @@
+package optional
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// Format implements fmt.Formatter. The %s and %q verbs, and their flags, are
+// applied to the string returned by String, as they were before optionals
+// implemented fmt.Formatter. Other verbs and flags are applied to the value
+// wrapped, or to the zero value of the type wrapped if there is no value
+// wrapped, so that %v prints the same as String. Two forms distinguish an
+// empty optional from one wrapping the zero value:
+//
+//	%+v	Some(value) or None
+//	%#v	optional.Of[T](value) or optional.Empty[T](), as returned by GoString
+func (o Optional[T]) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		io.WriteString(f, o.GoString())
+	case verb == 'v' && f.Flag('+'):
+		if v, ok := o.Get(); ok {
+			fmt.Fprintf(f, "Some("+formatString(f, verb)+")", v)
+		} else {
+			io.WriteString(f, "None")
+		}
+	case verb == 's' || verb == 'q':
+		fmt.Fprintf(f, formatString(f, verb), o.String())
+	default:
+		fmt.Fprintf(f, formatString(f, verb), o.ElseZero())
+	}
+}
+
+// GoString implements fmt.GoStringer, and returns the Go syntax of a call that
+// constructs the optional, with the value wrapped formatted with %#v.
+func (o Optional[T]) GoString() string {
+	typ := reflect.TypeOf((*T)(nil)).Elem().String()
+	if v, ok := o.Get(); ok {
+		return fmt.Sprintf("optional.Of[%s](%#v)", typ, v)
+	}
+	return fmt.Sprintf("optional.Empty[%s]()", typ)
+}
+
+// formatString returns a format string with the verb, and the flags, width,
+// and precision of the state.
+func formatString(f fmt.State, verb rune) string {
+	b := []byte{'%'}
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			b = append(b, byte(flag))
+		}
+	}
+	if w, ok := f.Width(); ok {
+		b = strconv.AppendInt(b, int64(w), 10)
+	}
+	if p, ok := f.Precision(); ok {
+		b = append(b, '.')
+		b = strconv.AppendInt(b, int64(p), 10)
+	}
+	b = append(b, string(verb)...)
+	return string(b)
+}
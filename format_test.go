@@ -0,0 +1,75 @@
+package optional
+
+import (
+	"fmt"
+	"testing"
+)
+
+type point struct {
+	X, Y int
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		Format   string
+		Optional any
+		Expected string
+	}{
+		{"%v", Empty[int](), "0"},
+		{"%v", Of(0), "0"},
+		{"%v", Of(1), "1"},
+		{"%s", Of("a"), "a"},
+		{"%d", Of(1), "1"},
+		{"%5d", Of(1), "    1"},
+		{"%-5d|", Of(1), "1    |"},
+		{"%05d", Of(1), "00001"},
+		{"%.2f", Of(1.0), "1.00"},
+		{"%8.2f", Of(1.0), "    1.00"},
+		{"%x", Of(255), "ff"},
+		{"%q", Of("a"), `"a"`},
+		{"%s", Of(5), "5"},
+		{"%s", Empty[int](), "0"},
+		{"%3s|", Of(5), "  5|"},
+		{"%q", Of(5), `"5"`},
+		{"%q", Empty[int](), `"0"`},
+		{"%v", Of(point{1, 2}), "{1 2}"},
+		{"%+v", Empty[int](), "None"},
+		{"%+v", Of(0), "Some(0)"},
+		{"%+v", Of("a"), "Some(a)"},
+		{"%+v", Of(point{1, 2}), "Some({X:1 Y:2})"},
+		{"%+5v", Of(1), "Some(    1)"},
+		{"%#v", Empty[int](), "optional.Empty[int]()"},
+		{"%#v", Of(0), "optional.Of[int](0)"},
+		{"%#v", Of("a"), `optional.Of[string]("a")`},
+		{"%#v", Of(point{1, 2}), "optional.Of[optional.point](optional.point{X:1, Y:2})"},
+		{"%#v", Empty[any](), "optional.Empty[interface {}]()"},
+		{"%T", Of(0), "optional.Optional[int]"},
+		{"%v", []Optional[int]{Empty[int](), Of(1)}, "[0 1]"},
+		{"%+v", []Optional[int]{Empty[int](), Of(1)}, "[None Some(1)]"},
+	}
+
+	for _, test := range tests {
+		s := fmt.Sprintf(test.Format, test.Optional)
+		if s != test.Expected {
+			t.Errorf("Sprintf(%q) got %q, want %q", test.Format, s, test.Expected)
+		}
+	}
+}
+
+func TestGoString(t *testing.T) {
+	tests := []struct {
+		Optional Optional[int]
+		Expected string
+	}{
+		{Empty[int](), "optional.Empty[int]()"},
+		{Of(0), "optional.Of[int](0)"},
+		{Of(1), "optional.Of[int](1)"},
+	}
+
+	for _, test := range tests {
+		s := test.Optional.GoString()
+		if s != test.Expected {
+			t.Errorf("GoString got %q, want %q", s, test.Expected)
+		}
+	}
+}
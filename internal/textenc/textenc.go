@@ -0,0 +1,146 @@
+// Package textenc parses and formats values as text, with the rules shared by the
+// packages of this module.
+package textenc
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Setter returns a function that sets the optional pointed to by v from text,
+// or false if v is not a pointer to an optional. Empty text sets the optional
+// to a value parsed from the empty string. Package optional assigns it when it
+// is initialized, so that the other packages of this module can set optionals
+// without optionals having an exported method for it.
+var Setter = func(v any) (set func(text string) error, ok bool) {
+	return nil, false
+}
+
+// Marshal returns the text form of the value. If the value implements
+// encoding.TextMarshaler it is used, otherwise values of the basic kinds are
+// formatted using strconv. Pointers are marshaled as the value they point to.
+func Marshal(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case encoding.TextMarshaler:
+		return v.MarshalText()
+	case time.Duration:
+		return []byte(v.String()), nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if !rv.IsNil() {
+			return Marshal(rv.Elem().Interface())
+		}
+	case reflect.String:
+		return []byte(rv.String()), nil
+	case reflect.Bool:
+		return strconv.AppendBool(nil, rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(nil, rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(nil, rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(nil, rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	case reflect.Complex64, reflect.Complex128:
+		return []byte(strconv.FormatComplex(rv.Complex(), 'g', -1, rv.Type().Bits())), nil
+	}
+	return nil, fmt.Errorf("optional: cannot marshal %T as text", v)
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// CanUnmarshal returns true if Unmarshal can parse text into a value of the
+// type.
+func CanUnmarshal(t reflect.Type) bool {
+	if t == durationType || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return CanUnmarshal(t.Elem())
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+// Unmarshal parses the text into the value pointed to by v. If the value
+// implements encoding.TextUnmarshaler it is used, otherwise values of the basic
+// kinds are parsed using strconv. If the value is a pointer, a new value is
+// allocated for it to point to and the text is parsed into that, so that
+// pointers to types that implement encoding.TextUnmarshaler with a pointer
+// receiver, such as *big.Int, can be parsed.
+func Unmarshal(text []byte, v any) error {
+	switch v := v.(type) {
+	case encoding.TextUnmarshaler:
+		return v.UnmarshalText(text)
+	case *time.Duration:
+		d, err := time.ParseDuration(string(text))
+		if err != nil {
+			return err
+		}
+		*v = d
+		return nil
+	}
+	rv := reflect.ValueOf(v).Elem()
+	s := string(text)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		p := reflect.New(rv.Type().Elem())
+		err := Unmarshal(text, p.Interface())
+		if err != nil {
+			return err
+		}
+		rv.Set(p)
+		return nil
+	case reflect.String:
+		rv.SetString(s)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+		return nil
+	case reflect.Complex64, reflect.Complex128:
+		c, err := strconv.ParseComplex(s, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetComplex(c)
+		return nil
+	}
+	return fmt.Errorf("optional: cannot unmarshal text into %s", rv.Type())
+}
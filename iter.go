@@ -0,0 +1,57 @@
+//go:build go1.23
+
+package optional
+
+import "iter"
+
+// All returns an iterator that yields the value wrapped by this optional, or
+// yields nothing if there is no value wrapped.
+func (o Optional[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if v, ok := o.Get(); ok {
+			yield(v)
+		}
+	}
+}
+
+// Values returns an iterator that yields the values wrapped by the optionals
+// in the sequence, skipping empty optionals.
+func Values[T any](seq iter.Seq[Optional[T]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for o := range seq {
+			if v, ok := o.Get(); ok && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// First returns an optional wrapping the first value in the sequence, or an
+// empty optional if the sequence is empty.
+func First[T any](seq iter.Seq[T]) Optional[T] {
+	for v := range seq {
+		return Of(v)
+	}
+	return Empty[T]()
+}
+
+// Find returns an optional wrapping the first value in the sequence for which
+// the function returns true, or an empty optional if there is none.
+func Find[T any](seq iter.Seq[T], f func(value T) bool) Optional[T] {
+	for v := range seq {
+		if f(v) {
+			return Of(v)
+		}
+	}
+	return Empty[T]()
+}
+
+// Last returns an optional wrapping the last value in the sequence, or an
+// empty optional if the sequence is empty.
+func Last[T any](seq iter.Seq[T]) Optional[T] {
+	o := Empty[T]()
+	for v := range seq {
+		o = Of(v)
+	}
+	return o
+}
@@ -0,0 +1,108 @@
+//go:build go1.23
+
+package optional
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	tests := []struct {
+		Optional       Optional[string]
+		ExpectedValues []string
+	}{
+		{Empty[string](), nil},
+		{Of(""), []string{""}},
+		{Of("string"), []string{"string"}},
+	}
+
+	for _, test := range tests {
+		values := slices.Collect(test.Optional.All())
+
+		if !slices.Equal(values, test.ExpectedValues) {
+			t.Errorf("%#v All got %#v, want %#v", test.Optional, values, test.ExpectedValues)
+		}
+	}
+}
+
+func TestAllBreak(t *testing.T) {
+	for range Of("string").All() {
+		break
+	}
+}
+
+func TestValues(t *testing.T) {
+	tests := []struct {
+		Optionals      []Optional[int]
+		ExpectedValues []int
+	}{
+		{nil, nil},
+		{[]Optional[int]{Empty[int]()}, nil},
+		{[]Optional[int]{Of(1), Empty[int](), Of(0), Empty[int](), Of(3)}, []int{1, 0, 3}},
+	}
+
+	for _, test := range tests {
+		values := slices.Collect(Values(slices.Values(test.Optionals)))
+
+		if !slices.Equal(values, test.ExpectedValues) {
+			t.Errorf("%#v Values got %#v, want %#v", test.Optionals, values, test.ExpectedValues)
+		}
+	}
+}
+
+func TestValuesBreak(t *testing.T) {
+	var values []int
+	for v := range Values(slices.Values([]Optional[int]{Of(1), Of(2), Of(3)})) {
+		values = append(values, v)
+		if v == 2 {
+			break
+		}
+	}
+
+	if !slices.Equal(values, []int{1, 2}) {
+		t.Errorf("Values with break got %#v, want %#v", values, []int{1, 2})
+	}
+}
+
+func TestFirstLast(t *testing.T) {
+	tests := []struct {
+		Values            []int
+		ExpectedFirst     int
+		ExpectedLast      int
+		ExpectedIsPresent bool
+	}{
+		{nil, 0, 0, false},
+		{[]int{0}, 0, 0, true},
+		{[]int{1, 2, 3}, 1, 3, true},
+	}
+
+	for _, test := range tests {
+		first, firstOk := First(slices.Values(test.Values)).Get()
+		last, lastOk := Last(slices.Values(test.Values)).Get()
+
+		if first != test.ExpectedFirst || firstOk != test.ExpectedIsPresent || last != test.ExpectedLast || lastOk != test.ExpectedIsPresent {
+			t.Errorf("%#v First, Last got %#v, %#v, %#v, %#v, want %#v, %#v, %#v", test.Values, first, firstOk, last, lastOk, test.ExpectedFirst, test.ExpectedLast, test.ExpectedIsPresent)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		Values            []int
+		ExpectedValue     int
+		ExpectedIsPresent bool
+	}{
+		{nil, 0, false},
+		{[]int{1, 3}, 0, false},
+		{[]int{1, 2, 3, 4}, 2, true},
+	}
+
+	for _, test := range tests {
+		value, ok := Find(slices.Values(test.Values), func(i int) bool { return i%2 == 0 }).Get()
+
+		if value != test.ExpectedValue || ok != test.ExpectedIsPresent {
+			t.Errorf("%#v Find got %#v, %#v, want %#v, %#v", test.Values, value, ok, test.ExpectedValue, test.ExpectedIsPresent)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"4d63.com/optional"
+	"4d63.com/optional/jsonschema"
+)
+
+func Example() {
+	type user struct {
+		Name string                 `json:"name"`
+		Age  optional.Optional[int] `json:"age,omitzero"`
+	}
+
+	s, err := jsonschema.For[user]()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	data, _ := json.MarshalIndent(s, "", "  ")
+	fmt.Println(string(data))
+
+	// Output:
+	// {
+	//   "$schema": "https://json-schema.org/draft/2020-12/schema",
+	//   "type": "object",
+	//   "properties": {
+	//     "age": {
+	//       "type": [
+	//         "integer",
+	//         "null"
+	//       ]
+	//     },
+	//     "name": {
+	//       "type": "string"
+	//     }
+	//   },
+	//   "required": [
+	//     "name"
+	//   ]
+	// }
+}
@@ -0,0 +1,370 @@
+// Package jsonschema generates JSON Schemas, draft 2020-12, for Go types that
+// contain optionals, describing the JSON that encoding/json marshals them to
+// and unmarshals them from.
+//
+// Struct fields are described by the properties of an object, named and
+// omitted by their json tags as encoding/json does. Fields are required
+// unless they are optionals or have the omitempty or omitzero option.
+//
+// Optional fields are never required, as they are omitted when empty if they
+// have the omitzero option, and can be missing from JSON being unmarshaled.
+// Empty optionals are marshaled as null, so their schemas also allow null,
+// such as {"type":["integer","null"]} for an Optional[int]. Empty
+// optional.OrZero values are marshaled as the zero value instead, so their
+// schemas do not allow null. Field schemas always allow null.
+//
+// Recursive struct types, such as a tree node with a slice of its children,
+// are described once under $defs, named by their type, and referred to with
+// $ref wherever they appear. Other struct types are described inline.
+//
+// Slices and arrays are arrays, except for byte slices, which are base64
+// strings. Maps are objects. Pointers, slices and maps allow null, which is
+// what they are marshaled as when nil. time.Time is a
+// date-time string, and types that implement encoding.TextMarshaler are
+// strings. Interfaces, and types that implement json.Marshaler, allow any
+// value.
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the URI of the JSON Schema draft of the schemas generated.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Type                 Type               `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Type is the type keyword of a schema, which lists the JSON types a value may
+// be. A Type with a single type is marshaled as a string, and with more than
+// one as an array.
+type Type []string
+
+// MarshalJSON marshals the type to a string if it has a single type, and
+// otherwise to an array.
+func (t Type) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON unmarshals a string or an array into the type.
+func (t *Type) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		*t = Type{s}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+// For returns the schema of the JSON of values of type T.
+func For[T any]() (*Schema, error) {
+	return ForType(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// ForType returns the schema of the JSON of values of the type. An error is
+// returned if the type cannot be marshaled to JSON, such as channels and
+// functions.
+func ForType(t reflect.Type) (*Schema, error) {
+	g := generator{
+		visiting:  map[reflect.Type]bool{},
+		recursive: map[reflect.Type]bool{},
+		defs:      map[string]*Schema{},
+		defNames:  map[reflect.Type]string{},
+	}
+	s, err := g.schema(t)
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = Draft
+	if len(g.defs) > 0 {
+		s.Defs = g.defs
+	}
+	return s, nil
+}
+
+const optionalPath = "4d63.com/optional"
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+type generator struct {
+	visiting  map[reflect.Type]bool
+	recursive map[reflect.Type]bool
+	defs      map[string]*Schema
+	defNames  map[reflect.Type]string
+}
+
+func (g *generator) schema(t reflect.Type) (*Schema, error) {
+	if elem, ok := optionalElem(t); ok {
+		s, err := g.schema(elem)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(t.Name(), "OrZero[") {
+			s = nullable(s)
+		}
+		return s, nil
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: Type{"string"}, Format: "date-time"}, nil
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return &Schema{}, nil
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return &Schema{Type: Type{"string"}}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: Type{"boolean"}}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: Type{"integer"}}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Type{"number"}}, nil
+	case reflect.String:
+		return &Schema{Type: Type{"string"}}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Pointer:
+		s, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(s), nil
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 && !reflect.PointerTo(t.Elem()).Implements(textMarshalerType) {
+			return &Schema{Type: Type{"string", "null"}, ContentEncoding: "base64"}, nil
+		}
+		items, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		s := &Schema{Type: Type{"array"}, Items: items}
+		if t.Kind() == reflect.Slice {
+			s = nullable(s)
+		}
+		return s, nil
+	case reflect.Map:
+		if !isKey(t.Key()) {
+			return nil, fmt.Errorf("jsonschema: unsupported map key type %s", t.Key())
+		}
+		values, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: Type{"object", "null"}, AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return g.structSchema(t)
+	}
+	return nil, fmt.Errorf("jsonschema: unsupported type %s", t)
+}
+
+func (g *generator) structSchema(t reflect.Type) (*Schema, error) {
+	if _, ok := g.defNames[t]; ok && !g.visiting[t] {
+		return g.ref(t), nil
+	}
+	if g.visiting[t] {
+		g.recursive[t] = true
+		return g.ref(t), nil
+	}
+	g.visiting[t] = true
+	defer delete(g.visiting, t)
+
+	s := &Schema{Type: Type{"object"}, Properties: map[string]*Schema{}}
+	err := g.addFields(s, t)
+	if err != nil {
+		return nil, err
+	}
+	if g.recursive[t] {
+		g.defs[g.defNames[t]] = s
+		return g.ref(t), nil
+	}
+	return s, nil
+}
+
+// ref returns a schema that refers to the definition of the struct type under
+// $defs, naming the definition if it has not been named yet.
+func (g *generator) ref(t reflect.Type) *Schema {
+	name, ok := g.defNames[t]
+	if !ok {
+		name = t.Name()
+		taken := map[string]bool{}
+		for _, n := range g.defNames {
+			taken[n] = true
+		}
+		for i := 2; taken[name]; i++ {
+			name = fmt.Sprintf("%s%d", t.Name(), i)
+		}
+		g.defNames[t] = name
+	}
+	return &Schema{Ref: "#/$defs/" + escapePointer(name)}
+}
+
+// escapePointer escapes the token for use in a JSON Pointer.
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// addFields adds the fields of the struct to the properties of the schema,
+// including the fields of embedded structs.
+func (g *generator) addFields(s *Schema, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if _, ok := optionalElem(ft); !ok {
+					err := g.addFields(s, ft)
+					if err != nil {
+						return err
+					}
+					continue
+				}
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fs, err := g.schema(f.Type)
+		if err != nil {
+			return err
+		}
+		if hasOption(opts, "string") && isQuotable(f.Type) {
+			fs = stringSchema(fs)
+		}
+		s.Properties[name] = fs
+		_, isOptional := optionalElem(f.Type)
+		if !isOptional && !hasOption(opts, "omitempty") && !hasOption(opts, "omitzero") {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return nil
+}
+
+// optionalElem returns the type wrapped if the type is an Optional, OrZero,
+// Nillable or Field.
+func optionalElem(t reflect.Type) (reflect.Type, bool) {
+	if t.PkgPath() != optionalPath {
+		return nil, false
+	}
+	switch name, _, _ := strings.Cut(t.Name(), "["); name {
+	case "Optional", "OrZero", "Nillable", "Field":
+	default:
+		return nil, false
+	}
+	get, ok := t.MethodByName("Get")
+	if !ok {
+		return nil, false
+	}
+	return get.Type.Out(0), true
+}
+
+// isQuotable returns true if the string option applies to the type, which it
+// does for booleans, numbers and strings.
+func isQuotable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// isKey returns true if encoding/json supports maps with keys of the type.
+func isKey(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return t.Implements(textMarshalerType)
+}
+
+// stringSchema returns the schema of a value marshaled with the string
+// option, which quotes booleans and numbers.
+func stringSchema(s *Schema) *Schema {
+	t := Type{}
+	for _, typ := range s.Type {
+		switch typ {
+		case "boolean", "integer", "number":
+			typ = "string"
+		}
+		t = append(t, typ)
+	}
+	s.Type = t
+	return s
+}
+
+// nullable returns the schema with null added to its type. A schema that
+// refers to a definition is wrapped in an anyOf with null instead.
+func nullable(s *Schema) *Schema {
+	if s.Ref != "" {
+		return &Schema{AnyOf: []*Schema{s, {Type: Type{"null"}}}}
+	}
+	s.Type = nullableType(s.Type)
+	return s
+}
+
+// nullableType returns the type with null added. An empty type, which allows
+// any value, already allows null.
+func nullableType(t Type) Type {
+	if len(t) == 0 {
+		return t
+	}
+	for _, typ := range t {
+		if typ == "null" {
+			return t
+		}
+	}
+	return append(t[:len(t):len(t)], "null")
+}
+
+func hasOption(opts, option string) bool {
+	for opts != "" {
+		var o string
+		o, opts, _ = strings.Cut(opts, ",")
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"4d63.com/optional"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+type basic struct {
+	Bool     bool    `json:"bool"`
+	Int      int     `json:"int"`
+	Uint8    uint8   `json:"uint8"`
+	Float64  float64 `json:"float64"`
+	String   string  `json:"string"`
+	Quoted   int     `json:"quoted,string"`
+	Omitted  string  `json:"omitted,omitempty"`
+	Zero     string  `json:"zero,omitzero"`
+	Untagged string
+	Ignored  string `json:"-"`
+	private  string
+}
+
+type optionals struct {
+	Int      optional.Optional[int]       `json:"int"`
+	String   optional.Optional[string]    `json:"string,omitzero"`
+	Time     optional.Optional[time.Time] `json:"time,omitzero"`
+	Slice    optional.Optional[[]int]     `json:"slice,omitzero"`
+	Struct   optional.Optional[address]   `json:"struct,omitzero"`
+	Nillable optional.Nillable[int]       `json:"nillable,omitzero"`
+	Field    optional.Field[string]       `json:"field,omitzero"`
+	Elements []optional.Optional[int]     `json:"elements"`
+}
+
+type orZeros struct {
+	Int    optional.OrZero[int]     `json:"int"`
+	String optional.OrZero[string]  `json:"string,omitzero"`
+	Slice  optional.OrZero[[]int]   `json:"slice,omitzero"`
+	Struct optional.OrZero[address] `json:"struct,omitzero"`
+}
+
+type address struct {
+	Street string                    `json:"street"`
+	Zip    optional.Optional[string] `json:"zip,omitzero"`
+}
+
+type Embedded struct {
+	Embedded string `json:"embedded"`
+}
+
+type composite struct {
+	Embedded
+	Time     time.Time         `json:"time"`
+	Duration time.Duration     `json:"duration"`
+	Bytes    []byte            `json:"bytes"`
+	Strings  []string          `json:"strings"`
+	Array    [2]int            `json:"array"`
+	Map      map[string]int    `json:"map"`
+	Pointer  *address          `json:"pointer"`
+	Any      any               `json:"any"`
+	Raw      json.RawMessage   `json:"raw"`
+	Nested   address           `json:"nested"`
+	Level    level             `json:"level"`
+	ByLevel  map[level]address `json:"by_level"`
+}
+
+type node struct {
+	Name     string `json:"name"`
+	Children []node `json:"children"`
+	Parent   *node  `json:"parent,omitempty"`
+}
+
+// packageNode refers to node from inside functions that declare their own node.
+type packageNode = node
+
+type tree struct {
+	Root   node            `json:"root"`
+	Orphan *node           `json:"orphan"`
+	Owner  address         `json:"owner"`
+	Admin  address         `json:"admin"`
+	Nodes  map[string]node `json:"nodes"`
+}
+
+type level int
+
+func (l level) MarshalText() ([]byte, error) {
+	return []byte("level"), nil
+}
+
+func TestForType(t *testing.T) {
+	tests := []struct {
+		Name string
+		Type reflect.Type
+	}{
+		{"basic", reflect.TypeOf(basic{})},
+		{"optionals", reflect.TypeOf(optionals{})},
+		{"or_zeros", reflect.TypeOf(orZeros{})},
+		{"composite", reflect.TypeOf(composite{})},
+		{"optional_int", reflect.TypeOf(optional.Optional[int]{})},
+		{"recursive", reflect.TypeOf(node{})},
+		{"tree", reflect.TypeOf(tree{})},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			s, err := ForType(test.Type)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.MarshalIndent(s, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			golden := filepath.Join("testdata", test.Name+".json")
+			if *update {
+				err := os.WriteFile(golden, got, 0o644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("ForType(%s) got:\n%s\nwant:\n%s", test.Type, got, want)
+			}
+		})
+	}
+}
+
+func TestFor(t *testing.T) {
+	s, err := For[optional.Optional[string]]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Schema != Draft || !reflect.DeepEqual(s.Type, Type{"string", "null"}) {
+		t.Errorf("For got %+v, want string or null schema", s)
+	}
+}
+
+func TestForTypeDefNames(t *testing.T) {
+	type node struct {
+		Children []node `json:"children"`
+	}
+	type both struct {
+		A node        `json:"a"`
+		B packageNode `json:"b"`
+	}
+
+	s, err := For[both]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Defs) != 2 || s.Properties["a"].Ref != "#/$defs/node" || s.Properties["b"].Ref != "#/$defs/node2" {
+		t.Errorf("For got defs %v and properties %v, want node and node2", s.Defs, s.Properties)
+	}
+}
+
+func TestForTypeErrors(t *testing.T) {
+	tests := []struct {
+		Type          reflect.Type
+		ExpectedError string
+	}{
+		{reflect.TypeOf(make(chan int)), "jsonschema: unsupported type chan int"},
+		{reflect.TypeOf(func() {}), "jsonschema: unsupported type func()"},
+		{reflect.TypeOf(map[address]int{}), "jsonschema: unsupported map key type jsonschema.address"},
+	}
+
+	for _, test := range tests {
+		_, err := ForType(test.Type)
+		if err == nil || err.Error() != test.ExpectedError {
+			t.Errorf("ForType(%s) got %v, want %v", test.Type, err, test.ExpectedError)
+		}
+	}
+}
+
+func TestTypeJSON(t *testing.T) {
+	tests := []struct {
+		Type Type
+		JSON string
+	}{
+		{Type{"string"}, `"string"`},
+		{Type{"string", "null"}, `["string","null"]`},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.Type)
+		if err != nil || string(data) != test.JSON {
+			t.Errorf("Marshal(%#v) got %s, %v, want %s", test.Type, data, err, test.JSON)
+		}
+		var typ Type
+		err = json.Unmarshal([]byte(test.JSON), &typ)
+		if err != nil || !reflect.DeepEqual(typ, test.Type) {
+			t.Errorf("Unmarshal(%s) got %#v, %v, want %#v", test.JSON, typ, err, test.Type)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package optional
+
+import (
+	"reflect"
+)
+
+// Merge returns a struct with each field set to the value of the field in the
+// layer with the highest precedence that sets the field. Later layers take
+// precedence over earlier layers, so layers are passed in order from lowest to
+// highest precedence, such as defaults, then a config file, then environment
+// variables, then flags.
+//
+// An optional field is set if it is present. A Field is set if it is defined,
+// so a field that is explicitly null in a layer overrides lower layers.
+// Fields of other types that have an IsZero method, such as time.Time, are set
+// if IsZero returns false. Nested structs are merged field by field, except
+// for structs without exported fields, which are merged as a single value.
+// Fields of any other type are set if they are not the zero value of their
+// type. A Result is one of those, so it is set if it holds an error or a value
+// that is not the zero value, because the zero value of a Result holds the zero
+// value of its type and cannot be told apart from a Result that is not set.
+// Unexported fields are left as the zero value.
+//
+// Merge panics if S is not a struct.
+func Merge[S any](layers ...S) S {
+	merged, _ := MergeSources(layers...)
+	return merged
+}
+
+// MergeSources is like Merge, but also returns the index of the layer that
+// set each field, keyed by the path of the field, which is the names of the
+// fields leading to it joined by dots, such as "DB.Host". Fields not set by
+// any layer are not in the map. It is intended for debugging where a merged
+// value came from.
+func MergeSources[S any](layers ...S) (merged S, sources map[string]int) {
+	v := reflect.ValueOf(&merged).Elem()
+	if v.Kind() != reflect.Struct {
+		panic("optional: Merge of non-struct type " + v.Type().String())
+	}
+	values := make([]reflect.Value, len(layers))
+	for i := range layers {
+		values[i] = reflect.ValueOf(&layers[i]).Elem()
+	}
+	sources = map[string]int{}
+	mergeStruct(v, values, "", sources)
+	return merged, sources
+}
+
+type isZeroer interface {
+	IsZero() bool
+}
+
+var isZeroerType = reflect.TypeOf((*isZeroer)(nil)).Elem()
+
+func mergeStruct(dst reflect.Value, layers []reflect.Value, path string, sources map[string]int) {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fieldPath := f.Name
+		if path != "" {
+			fieldPath = path + "." + f.Name
+		}
+		fieldLayers := make([]reflect.Value, len(layers))
+		for j, l := range layers {
+			fieldLayers[j] = l.Field(i)
+		}
+		if isStruct(f.Type) {
+			mergeStruct(dst.Field(i), fieldLayers, fieldPath, sources)
+			continue
+		}
+		for j := len(fieldLayers) - 1; j >= 0; j-- {
+			if isSet(fieldLayers[j]) {
+				dst.Field(i).Set(fieldLayers[j])
+				sources[fieldPath] = j
+				break
+			}
+		}
+	}
+}
+
+// isStruct returns true if the type is a struct that is merged field by field.
+func isStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t.Implements(isZeroerType) {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// isSet returns true if the value is set for the purposes of merging.
+func isSet(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return false
+		}
+	}
+	if z, ok := v.Interface().(isZeroer); ok {
+		return !z.IsZero()
+	}
+	return !v.IsZero()
+}
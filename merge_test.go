@@ -0,0 +1,154 @@
+package optional
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type mergeConfig struct {
+	Addr    Optional[string]
+	Port    Optional[int]
+	Debug   Optional[bool]
+	Name    Field[string]
+	Started time.Time
+	Workers int
+	Tags    []string
+	DB      mergeDatabase
+	private int
+}
+
+type mergeDatabase struct {
+	Host Optional[string]
+	Port Optional[int]
+}
+
+func TestMerge(t *testing.T) {
+	defaults := mergeConfig{
+		Addr:    Of("localhost"),
+		Port:    Of(80),
+		Debug:   Of(false),
+		Name:    FieldOf("default"),
+		Workers: 1,
+		DB:      mergeDatabase{Host: Of("db"), Port: Of(5432)},
+		private: 1,
+	}
+	file := mergeConfig{
+		Port:    Of(8080),
+		Name:    Null[string](),
+		Started: time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC),
+		Tags:    []string{"a"},
+		DB:      mergeDatabase{Host: Of("db.example.com")},
+	}
+	env := mergeConfig{
+		Debug: Of(true),
+	}
+	flags := mergeConfig{
+		Port:    Of(0),
+		Workers: 4,
+	}
+
+	merged, sources := MergeSources(defaults, file, env, flags)
+
+	if merged.Addr != Of("localhost") {
+		t.Errorf("Addr got %#v, want %#v", merged.Addr, Of("localhost"))
+	}
+	if merged.Port != Of(0) {
+		t.Errorf("Port got %#v, want %#v", merged.Port, Of(0))
+	}
+	if merged.Debug != Of(true) {
+		t.Errorf("Debug got %#v, want %#v", merged.Debug, Of(true))
+	}
+	if !merged.Name.IsNull() {
+		t.Errorf("Name got %#v, want null", merged.Name)
+	}
+	if !merged.Started.Equal(file.Started) {
+		t.Errorf("Started got %v, want %v", merged.Started, file.Started)
+	}
+	if merged.Workers != 4 {
+		t.Errorf("Workers got %#v, want %#v", merged.Workers, 4)
+	}
+	if len(merged.Tags) != 1 || merged.Tags[0] != "a" {
+		t.Errorf("Tags got %#v, want %#v", merged.Tags, []string{"a"})
+	}
+	if merged.DB.Host != Of("db.example.com") || merged.DB.Port != Of(5432) {
+		t.Errorf("DB got %#v, want %#v, %#v", merged.DB, Of("db.example.com"), Of(5432))
+	}
+	if merged.private != 0 {
+		t.Errorf("private got %#v, want %#v", merged.private, 0)
+	}
+
+	wantSources := map[string]int{
+		"Addr":    0,
+		"Port":    3,
+		"Debug":   2,
+		"Name":    1,
+		"Started": 1,
+		"Workers": 3,
+		"Tags":    1,
+		"DB.Host": 1,
+		"DB.Port": 0,
+	}
+	if len(sources) != len(wantSources) {
+		t.Errorf("sources got %v, want %v", sources, wantSources)
+	}
+	for path, want := range wantSources {
+		if got, ok := sources[path]; !ok || got != want {
+			t.Errorf("sources[%q] got %v, %v, want %v", path, got, ok, want)
+		}
+	}
+
+	if m := Merge(defaults, file, env, flags); m.Port != merged.Port || m.DB != merged.DB {
+		t.Errorf("Merge got %#v, want %#v", m, merged)
+	}
+}
+
+func TestMergeResult(t *testing.T) {
+	type config struct {
+		Value Result[int]
+		Error Result[int]
+		Zero  Result[int]
+	}
+	errFailed := errors.New("failed")
+	low := config{Value: Ok(1), Error: Ok(1), Zero: Ok(1)}
+	high := config{Value: Ok(2), Error: Err[int](errFailed), Zero: Ok(0)}
+
+	merged, sources := MergeSources(low, high)
+
+	if merged.Value != Ok(2) {
+		t.Errorf("Value got %#v, want %#v", merged.Value, Ok(2))
+	}
+	if merged.Error.Err() != errFailed {
+		t.Errorf("Error got %v, want %v", merged.Error.Err(), errFailed)
+	}
+	if merged.Zero != Ok(1) {
+		t.Errorf("Zero got %#v, want %#v", merged.Zero, Ok(1))
+	}
+	wantSources := map[string]int{"Value": 1, "Error": 1, "Zero": 0}
+	for path, want := range wantSources {
+		if got, ok := sources[path]; !ok || got != want {
+			t.Errorf("sources[%q] got %v, %v, want %v", path, got, ok, want)
+		}
+	}
+}
+
+func TestMergeNone(t *testing.T) {
+	merged, sources := MergeSources[mergeDatabase]()
+	if merged != (mergeDatabase{}) || len(sources) != 0 {
+		t.Errorf("MergeSources got %#v, %v, want zero value and no sources", merged, sources)
+	}
+
+	merged, sources = MergeSources(mergeDatabase{}, mergeDatabase{})
+	if merged != (mergeDatabase{}) || len(sources) != 0 {
+		t.Errorf("MergeSources got %#v, %v, want zero value and no sources", merged, sources)
+	}
+}
+
+func TestMergeNonStruct(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Merge of int got no panic, want panic")
+		}
+	}()
+	Merge(1, 2)
+}
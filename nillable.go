@@ -0,0 +1,44 @@
+package optional
+
+import (
+	"encoding/xml"
+)
+
+// XSINamespace is the XML Schema instance namespace, which the xsi prefix of
+// the xsi:nil attribute is conventionally bound to. Declare it on the root
+// element of documents with Nillable elements with a field such as:
+//
+//	XSI string `xml:"xmlns:xsi,attr"`
+//
+// set to XSINamespace.
+const XSINamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// Nillable is an Optional that is marshaled to XML as an empty element with
+// the xsi:nil="true" attribute when it is empty, instead of being omitted, as
+// required by XML schemas with nillable elements.
+//
+// The attribute is written with the conventional xsi prefix, which some
+// consumers match literally, and the prefix is not declared on the element.
+// encoding/xml cannot tell whether an ancestor has declared it, so declare it
+// once on the root element, as described for XSINamespace.
+//
+// All other methods are those of the Optional it embeds. Optionals, including
+// Nillable, unmarshal elements with xsi:nil="true" as empty optionals.
+type Nillable[T any] struct {
+	Optional[T]
+}
+
+// MarshalXML marshals the value being wrapped to XML. If there is no value
+// being wrapped, an empty element with the xsi:nil="true" attribute is
+// marshaled.
+func (n Nillable[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if n.IsPresent() {
+		return n.Optional.MarshalXML(e, start)
+	}
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"})
+	err := e.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
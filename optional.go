@@ -4,21 +4,35 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
-)
-
-type Optional[T any] optional[T]
 
-type optional[T any] []T
-
-const (
-	valueKey = iota
+	"4d63.com/optional/internal/textenc"
 )
 
+// Optional wraps a value of type T, or is empty to represent the lack of a
+// value. The zero value of an Optional is empty. The value wrapped can only be
+// accessed through an Optional's methods, which never expose the value when
+// the Optional is empty.
+//
+// Optionals are structs that hold their value inline, so wrapping a value does
+// not allocate. Optionals implement IsZero, returning true when they are empty,
+// so that they are omitted by encoding/json when a field has the omitzero
+// option.
+//
+// Optionals of comparable types are comparable, so they can be compared with
+// == and used as map keys, and structs with optional fields can be too. An
+// empty optional is never equal to an optional wrapping the zero value.
+type Optional[T any] struct {
+	value   T
+	present bool
+}
+
 // Of wraps the value in an optional.
 func Of[T any](value T) Optional[T] {
-	return Optional[T]{valueKey: value}
+	return Optional[T]{value: value, present: true}
 }
 
+// OfPtr wraps the value pointed to by the pointer in an optional, or returns an
+// empty optional if the pointer is nil.
 func OfPtr[T any](ptr *T) Optional[T] {
 	if ptr == nil {
 		return Empty[T]()
@@ -29,34 +43,48 @@ func OfPtr[T any](ptr *T) Optional[T] {
 
 // Empty returns an empty optional.
 func Empty[T any]() Optional[T] {
-	return nil
+	return Optional[T]{}
 }
 
 // Get returns the value wrapped by this optional, and an ok signal for whether a value was wrapped.
 func (o Optional[T]) Get() (value T, ok bool) {
-	o.If(func(v T) {
-		value = v
-		ok = true
-	})
-	return
+	return o.value, o.present
 }
 
 // IsPresent returns true if there is a value wrapped by this optional.
 func (o Optional[T]) IsPresent() bool {
-	return o != nil
+	return o.present
+}
+
+// IsZero returns true if there is no value wrapped by this optional. It is
+// used by encoding/json to omit empty optionals from fields with the omitzero
+// option.
+func (o Optional[T]) IsZero() bool {
+	return !o.present
+}
+
+// Ptr returns a pointer to a copy of the value wrapped by this optional, or nil
+// if there is no value wrapped by this optional.
+func (o Optional[T]) Ptr() *T {
+	if v, ok := o.Get(); ok {
+		return &v
+	}
+	return nil
 }
 
 // If calls the function if there is a value wrapped by this optional.
 func (o Optional[T]) If(f func(value T)) {
 	if o.IsPresent() {
-		f(o[valueKey])
+		f(o.value)
 	}
 }
 
+// ElseFunc returns the value wrapped by this optional, or the value returned by
+// the function if there is no value wrapped by this optional. The function is
+// only called if there is no value wrapped.
 func (o Optional[T]) ElseFunc(f func() T) (value T) {
 	if o.IsPresent() {
-		o.If(func(v T) { value = v })
-		return
+		return o.value
 	} else {
 		return f()
 	}
@@ -75,6 +103,15 @@ func (o Optional[T]) ElseZero() (value T) {
 	return o.Else(zero)
 }
 
+// Or returns this optional if there is a value wrapped by it, otherwise the
+// other optional.
+func (o Optional[T]) Or(other Optional[T]) Optional[T] {
+	if o.IsPresent() {
+		return o
+	}
+	return other
+}
+
 // String returns the string representation of the wrapped value, or the string
 // representation of the zero value of the type wrapped if there is no value
 // wrapped by this optional.
@@ -82,14 +119,23 @@ func (o Optional[T]) String() string {
 	return fmt.Sprintf("%v", o.ElseZero())
 }
 
-// MarshalJSON marshals the value being wrapped to JSON. If there is no vale
-// being wrapped, the zero value of its type is marshaled.
+// MarshalJSON marshals the value being wrapped to JSON. If there is no value
+// being wrapped, null is marshaled. Use OrZero to marshal the zero value of its
+// type instead.
 func (o Optional[T]) MarshalJSON() (data []byte, err error) {
+	if !o.IsPresent() {
+		return []byte("null"), nil
+	}
 	return json.Marshal(o.ElseZero())
 }
 
-// UnmarshalJSON unmarshals the JSON into a value wrapped by this optional.
+// UnmarshalJSON unmarshals the JSON into a value wrapped by this optional. If
+// the JSON is null the optional is empty.
 func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = Empty[T]()
+		return nil
+	}
 	var v T
 	err := json.Unmarshal(data, &v)
 	if err != nil {
@@ -99,14 +145,88 @@ func (o *Optional[T]) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// MarshalXML marshals the value being wrapped to XML. If there is no vale
-// being wrapped, the zero value of its type is marshaled.
+// MarshalText marshals the value being wrapped to text. If the type wrapped
+// implements encoding.TextMarshaler it is used, otherwise values of the basic
+// kinds are formatted using strconv. If there is no value being wrapped, the
+// empty string is marshaled, which is also the text of an optional wrapping an
+// empty string, so the two collide when used as JSON map keys.
+func (o Optional[T]) MarshalText() (text []byte, err error) {
+	v, ok := o.Get()
+	if !ok {
+		return []byte{}, nil
+	}
+	return textenc.Marshal(v)
+}
+
+// UnmarshalText unmarshals the text into a value wrapped by this optional. If
+// the type wrapped implements encoding.TextUnmarshaler it is used, otherwise
+// values of the basic kinds are parsed using strconv. If the text is empty the
+// optional is empty, including when the type wrapped is a string.
+func (o *Optional[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*o = Empty[T]()
+		return nil
+	}
+	var v T
+	err := textenc.Unmarshal(text, &v)
+	if err != nil {
+		return err
+	}
+	*o = Of(v)
+	return nil
+}
+
+// setText parses the text into a value wrapped by this optional, with the same
+// rules as UnmarshalText, except that empty text is parsed into a value and
+// does not make the optional empty. It sets an optional from text that is
+// known to be present, such as a flag that was passed or an environment
+// variable that is set.
+func (o *Optional[T]) setText(text string) error {
+	var v T
+	err := textenc.Unmarshal([]byte(text), &v)
+	if err != nil {
+		return err
+	}
+	*o = Of(v)
+	return nil
+}
+
+// textSetter is implemented by pointers to optionals.
+type textSetter interface {
+	setText(text string) error
+}
+
+func init() {
+	textenc.Setter = func(v any) (func(text string) error, bool) {
+		s, ok := v.(textSetter)
+		if !ok {
+			return nil, false
+		}
+		return s.setText, true
+	}
+}
+
+// MarshalXML marshals the value being wrapped to XML. If there is no value
+// being wrapped, nothing is marshaled and the element is omitted, whether or
+// not the field has the omitempty option. encoding/xml never applies omitempty
+// to structs, so an Optional cannot tell whether it was set, and omitting empty
+// optionals keeps fields with omitempty behaving as they did when optionals
+// were slices. Use OrZero to marshal the zero value of its type instead, or
+// Nillable to marshal an element with xsi:nil="true".
 func (o Optional[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	return e.EncodeElement(o.ElseZero(), start)
+	if !o.IsPresent() {
+		return nil
+	}
+	return e.EncodeElement(o.value, start)
 }
 
-// UnmarshalXML unmarshals the XML into a value wrapped by this optional.
+// UnmarshalXML unmarshals the XML into a value wrapped by this optional. If
+// the element has the xsi:nil="true" attribute the optional is empty.
 func (o *Optional[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if isXSINil(start) {
+		*o = Empty[T]()
+		return d.Skip()
+	}
 	var v T
 	err := d.DecodeElement(&v, &start)
 	if err != nil {
@@ -115,3 +235,41 @@ func (o *Optional[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error
 	*o = Of(v)
 	return nil
 }
+
+// isXSINil returns true if the element has the xsi:nil attribute set to true.
+func isXSINil(start xml.StartElement) bool {
+	for _, a := range start.Attr {
+		if a.Name.Local == "nil" && (a.Name.Space == XSINamespace || a.Name.Space == "xsi") {
+			return a.Value == "true" || a.Value == "1"
+		}
+	}
+	return false
+}
+
+// MarshalXMLAttr marshals the value being wrapped to an XML attribute with the
+// text form of the value. If there is no value being wrapped, the attribute is
+// omitted.
+func (o Optional[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	v, ok := o.Get()
+	if !ok {
+		return xml.Attr{}, nil
+	}
+	text, err := textenc.Marshal(v)
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// UnmarshalXMLAttr unmarshals the XML attribute into a value wrapped by this
+// optional. An attribute that is present is always unmarshaled into a value,
+// even if the attribute's value is empty.
+func (o *Optional[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	var v T
+	err := textenc.Unmarshal([]byte(attr.Value), &v)
+	if err != nil {
+		return err
+	}
+	*o = Of(v)
+	return nil
+}
@@ -1,6 +1,9 @@
 package optional
 
-import "testing"
+import (
+	"encoding/xml"
+	"testing"
+)
 
 func TestIsPresent(t *testing.T) {
 	s := "ptr to string"
@@ -143,3 +146,186 @@ func TestElseZero(t *testing.T) {
 		}
 	}
 }
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		Optional        Optional[int]
+		ExpectedMarshal string
+	}{
+		{Empty[int](), `null`},
+		{Of(0), `0`},
+		{Of(1), `1`},
+	}
+
+	for _, test := range tests {
+		data, err := test.Optional.MarshalJSON()
+
+		if err != nil || string(data) != test.ExpectedMarshal {
+			t.Errorf("%#v MarshalJSON got %#v, %v, want %#v", test.Optional, string(data), err, test.ExpectedMarshal)
+		}
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		JSON              string
+		ExpectedValue     int
+		ExpectedIsPresent bool
+	}{
+		{`null`, 0, false},
+		{`0`, 0, true},
+		{`1`, 1, true},
+	}
+
+	for _, test := range tests {
+		o := Of(100)
+		err := o.UnmarshalJSON([]byte(test.JSON))
+		value, ok := o.Get()
+
+		if err != nil || value != test.ExpectedValue || ok != test.ExpectedIsPresent {
+			t.Errorf("%#v UnmarshalJSON got %#v, %#v, %v, want %#v, %#v", test.JSON, value, ok, err, test.ExpectedValue, test.ExpectedIsPresent)
+		}
+	}
+}
+
+func TestUnmarshalXMLAttr(t *testing.T) {
+	tests := []struct {
+		Value             string
+		ExpectedValue     string
+		ExpectedIsPresent bool
+	}{
+		{"", "", true},
+		{"string", "string", true},
+	}
+
+	for _, test := range tests {
+		o := Empty[string]()
+		err := o.UnmarshalXMLAttr(xml.Attr{Name: xml.Name{Local: "a"}, Value: test.Value})
+		value, ok := o.Get()
+
+		if err != nil || value != test.ExpectedValue || ok != test.ExpectedIsPresent {
+			t.Errorf("%#v UnmarshalXMLAttr got %#v, %#v, %v, want %#v, %#v", test.Value, value, ok, err, test.ExpectedValue, test.ExpectedIsPresent)
+		}
+	}
+
+	o := Empty[int]()
+	err := o.UnmarshalXMLAttr(xml.Attr{Name: xml.Name{Local: "a"}, Value: ""})
+	if err == nil {
+		t.Errorf("UnmarshalXMLAttr into int got nil error, want error")
+	}
+}
+
+func TestMarshalXMLNil(t *testing.T) {
+	tests := []struct {
+		Nillable        Nillable[int]
+		ExpectedMarshal string
+	}{
+		{Nillable[int]{Optional: Empty[int]()}, `<s xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><i xsi:nil="true"></i></s>`},
+		{Nillable[int]{Optional: Of(0)}, `<s xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><i>0</i></s>`},
+		{Nillable[int]{Optional: Of(1)}, `<s xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><i>1</i></s>`},
+	}
+
+	type s struct {
+		XMLName xml.Name      `xml:"s"`
+		XSI     string        `xml:"xmlns:xsi,attr"`
+		I       Nillable[int] `xml:"i"`
+	}
+	for _, test := range tests {
+		data, err := xml.Marshal(s{XSI: XSINamespace, I: test.Nillable})
+
+		if err != nil || string(data) != test.ExpectedMarshal {
+			t.Errorf("%#v MarshalXML got %#v, %v, want %#v", test.Nillable, string(data), err, test.ExpectedMarshal)
+		}
+
+		var u s
+		err = xml.Unmarshal(data, &u)
+
+		if err != nil || u.I != test.Nillable {
+			t.Errorf("%#v UnmarshalXML got %#v, %v, want %#v", string(data), u.I, err, test.Nillable)
+		}
+	}
+}
+
+func TestUnmarshalXMLNil(t *testing.T) {
+	tests := []struct {
+		XML               string
+		ExpectedValue     int
+		ExpectedIsPresent bool
+	}{
+		{`<s><i>1</i></s>`, 1, true},
+		{`<s><i xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></i></s>`, 0, false},
+		{`<s xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><i xsi:nil="true"/></s>`, 0, false},
+		{`<s xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><i xsi:nil="1"/></s>`, 0, false},
+		{`<s><i xsi:nil="true"/></s>`, 0, false},
+		{`<s xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><i xsi:nil="false">1</i></s>`, 1, true},
+	}
+
+	for _, test := range tests {
+		s := struct {
+			XMLName xml.Name      `xml:"s"`
+			I       Optional[int] `xml:"i"`
+		}{I: Of(100)}
+		err := xml.Unmarshal([]byte(test.XML), &s)
+		value, ok := s.I.Get()
+
+		if err != nil || value != test.ExpectedValue || ok != test.ExpectedIsPresent {
+			t.Errorf("%#v UnmarshalXML got %#v, %#v, %v, want %#v, %#v", test.XML, value, ok, err, test.ExpectedValue, test.ExpectedIsPresent)
+		}
+	}
+}
+
+func TestOr(t *testing.T) {
+	tests := []struct {
+		Optional          Optional[string]
+		Other             Optional[string]
+		ExpectedValue     string
+		ExpectedIsPresent bool
+	}{
+		{Empty[string](), Empty[string](), "", false},
+		{Empty[string](), Of("other"), "other", true},
+		{Of(""), Of("other"), "", true},
+		{Of("string"), Empty[string](), "string", true},
+		{Of("string"), Of("other"), "string", true},
+	}
+
+	for _, test := range tests {
+		value, ok := test.Optional.Or(test.Other).Get()
+
+		if value != test.ExpectedValue || ok != test.ExpectedIsPresent {
+			t.Errorf("%#v Or(%#v) got %#v, %#v, want %#v, %#v", test.Optional, test.Other, value, ok, test.ExpectedValue, test.ExpectedIsPresent)
+		}
+	}
+}
+
+func TestPtr(t *testing.T) {
+	s := "ptr to string"
+	tests := []struct {
+		Optional      Optional[string]
+		ExpectedIsNil bool
+		ExpectedValue string
+	}{
+		{Empty[string](), true, ""},
+		{Of(""), false, ""},
+		{Of("string"), false, "string"},
+		{OfPtr((*string)(nil)), true, ""},
+		{OfPtr((*string)(&s)), false, "ptr to string"},
+	}
+
+	for _, test := range tests {
+		ptr := test.Optional.Ptr()
+
+		if (ptr == nil) != test.ExpectedIsNil || (ptr != nil && *ptr != test.ExpectedValue) {
+			t.Errorf("%#v Ptr got %#v, want nil %#v, value %#v", test.Optional, ptr, test.ExpectedIsNil, test.ExpectedValue)
+		}
+	}
+
+	o := OfPtr(&s)
+	ptr := o.Ptr()
+	if ptr == &s {
+		t.Errorf("Ptr got the original pointer, want a pointer to a copy")
+	}
+	*ptr = "modified"
+	if v, _ := o.Get(); v != "ptr to string" {
+		t.Errorf("Ptr modification changed optional value to %#v", v)
+	}
+}
@@ -0,0 +1,25 @@
+// Command optionalcheck reports misuse of the types in package
+// 4d63.com/optional, and optional fields that are not omitted from JSON when
+// empty.
+//
+// It can be run directly:
+//
+//	optionalcheck ./...
+//
+// Or by go vet:
+//
+//	go vet -vettool=$(which optionalcheck) ./...
+package main
+
+import (
+	"4d63.com/optional/optionalcheck"
+	"4d63.com/optional/optionalcheck/optionaltag"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+func main() {
+	multichecker.Main(
+		optionalcheck.Analyzer,
+		optionaltag.Analyzer,
+	)
+}
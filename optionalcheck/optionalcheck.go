@@ -0,0 +1,138 @@
+// Package optionalcheck defines an Analyzer that reports misuse of the types
+// in package 4d63.com/optional.
+package optionalcheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const doc = `report misuse of optional types
+
+The optionalcheck analyzer reports code that bypasses the safety of the
+Optional, Field and Result types in package 4d63.com/optional:
+
+  - composite literals such as optional.Optional[int]{}, which should be
+    written with the package's constructors, such as optional.Empty[int]();
+  - discarding the ok result of Get while using the value, such as
+    v, _ := o.Get(), which uses the zero value when empty without saying so.
+
+Indexing, slicing, len, cap, append, and comparisons to nil are not
+reported, because the optional types are structs with unexported fields and
+the compiler rejects those operations.`
+
+// Analyzer reports misuse of the types in package 4d63.com/optional.
+var Analyzer = &analysis.Analyzer{
+	Name:     "optionalcheck",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+const optionalPath = "4d63.com/optional"
+
+// constructors maps the name of each type that should not be constructed with
+// a composite literal to the constructors that should be used instead.
+var constructors = map[string]string{
+	"Optional": "optional.Empty or optional.Of",
+	"Field":    "optional.Undefined, optional.Null or optional.FieldOf",
+}
+
+// getters are the names of the types with a Get method that returns a value
+// and an ok signal.
+var getters = map[string]bool{
+	"Optional": true,
+	"Field":    true,
+	"Result":   true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if pass.Pkg.Path() == optionalPath {
+		return nil, nil
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.CompositeLit)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			checkCompositeLit(pass, n)
+		case *ast.AssignStmt:
+			if len(n.Lhs) == 2 && len(n.Rhs) == 1 {
+				checkGet(pass, n.Lhs[0], n.Lhs[1], n.Rhs[0])
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == 2 && len(n.Values) == 1 {
+				checkGet(pass, n.Names[0], n.Names[1], n.Values[0])
+			}
+		}
+	})
+	return nil, nil
+}
+
+// checkCompositeLit reports composite literals of optional types.
+func checkCompositeLit(pass *analysis.Pass, lit *ast.CompositeLit) {
+	name, ok := optionalTypeName(pass.TypesInfo.TypeOf(lit))
+	if !ok {
+		return
+	}
+	if use, ok := constructors[name]; ok {
+		pass.ReportRangef(lit, "composite literal of optional.%s; use %s", name, use)
+	}
+}
+
+// checkGet reports calls to Get on optional types where the ok result is
+// assigned to the blank identifier and the value is not.
+func checkGet(pass *analysis.Pass, value, ok ast.Expr, rhs ast.Expr) {
+	if !isBlank(ok) || isBlank(value) {
+		return
+	}
+	call, isCall := ast.Unparen(rhs).(*ast.CallExpr)
+	if !isCall {
+		return
+	}
+	fn, isFunc := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !isFunc || fn.Name() != "Get" {
+		return
+	}
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return
+	}
+	name, isOptional := optionalTypeName(recv.Type())
+	if !isOptional || !getters[name] {
+		return
+	}
+	pass.ReportRangef(ok, "ok result of optional.%s.Get is discarded but the value is used; check ok, or use ElseZero to use the zero value when empty", name)
+}
+
+// optionalTypeName returns the name of the type if it is a type defined in
+// package optional.
+func optionalTypeName(t types.Type) (string, bool) {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return "", false
+	}
+	obj := named.Origin().Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != optionalPath {
+		return "", false
+	}
+	return obj.Name(), true
+}
+
+func isBlank(e ast.Expr) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == "_"
+}
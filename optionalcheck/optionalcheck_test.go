@@ -0,0 +1,12 @@
+package optionalcheck_test
+
+import (
+	"testing"
+
+	"4d63.com/optional/optionalcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func Test(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), optionalcheck.Analyzer, "a")
+}
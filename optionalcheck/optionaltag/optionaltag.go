@@ -0,0 +1,186 @@
+// Package optionaltag defines an Analyzer that reports fields of the types in
+// package 4d63.com/optional that are not omitted from JSON when empty.
+package optionaltag
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `report optional fields without omitzero in their json tag
+
+The optionaltag analyzer reports struct fields of type Optional or Field from
+package 4d63.com/optional that have a json struct tag without the omitzero
+option. Without omitzero, empty optionals and undefined fields are marshaled
+as null. The omitempty option does not omit optionals because they are
+structs, so fields with omitempty are reported too.
+
+Fields without a json tag are not reported. XML tags are not reported,
+because empty optionals are always omitted from XML.
+
+A suggested fix adds omitzero to the tag, replacing omitempty if present.`
+
+// Analyzer reports optional fields without omitzero in their json tag.
+var Analyzer = &analysis.Analyzer{
+	Name:     "optionaltag",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+const optionalPath = "4d63.com/optional"
+
+// omittable are the names of the types that are omitted by omitzero when
+// empty or undefined.
+var omittable = map[string]bool{
+	"Optional": true,
+	"Field":    true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.StructType)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		for _, field := range n.(*ast.StructType).Fields.List {
+			checkField(pass, field)
+		}
+	})
+	return nil, nil
+}
+
+func checkField(pass *analysis.Pass, field *ast.Field) {
+	if field.Tag == nil {
+		return
+	}
+	name, ok := optionalTypeName(pass.TypesInfo.TypeOf(field.Type))
+	if !ok || !omittable[name] {
+		return
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return
+	}
+	value, start, end, ok := lookupTag(tag, "json")
+	if !ok || value == "-" {
+		return
+	}
+	opts := strings.Split(value, ",")
+	newOpts := make([]string, 0, len(opts)+1)
+	hasOmitEmpty := false
+	for i, opt := range opts {
+		switch {
+		case i > 0 && opt == "omitzero":
+			return
+		case i > 0 && opt == "omitempty":
+			hasOmitEmpty = true
+		default:
+			newOpts = append(newOpts, opt)
+		}
+	}
+	newOpts = append(newOpts, "omitzero")
+	newTag := tag[:start] + strconv.Quote(strings.Join(newOpts, ",")) + tag[end:]
+
+	var msg string
+	if hasOmitEmpty {
+		msg = fmt.Sprintf("json tag of optional.%s field has omitempty, which does not omit empty values; use omitzero", name)
+	} else {
+		msg = fmt.Sprintf("json tag of optional.%s field does not have omitzero; empty values are marshaled as null", name)
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:     field.Tag.Pos(),
+		End:     field.Tag.End(),
+		Message: msg,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Add omitzero to json tag",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     field.Tag.Pos(),
+				End:     field.Tag.End(),
+				NewText: []byte(quoteTag(newTag, field.Tag)),
+			}},
+		}},
+	})
+}
+
+// quoteTag quotes the tag in the same style as the original literal, using a
+// raw string if possible.
+func quoteTag(tag string, original *ast.BasicLit) string {
+	if original.Kind == token.STRING && strings.HasPrefix(original.Value, "`") && !strings.Contains(tag, "`") {
+		return "`" + tag + "`"
+	}
+	return strconv.Quote(tag)
+}
+
+// lookupTag returns the value associated with the key in the struct tag, and
+// the start and end offsets of the quoted value in the tag. It parses the tag
+// the same way as reflect.StructTag.Lookup.
+func lookupTag(tag, key string) (value string, start, end int, ok bool) {
+	offset := 0
+	for tag != "" {
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		offset += i
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		name := tag[:i]
+		offset += i + 1
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		qvalue := tag[:i+1]
+		if name == key {
+			value, err := strconv.Unquote(qvalue)
+			if err != nil {
+				break
+			}
+			return value, offset, offset + i + 1, true
+		}
+		offset += i + 1
+		tag = tag[i+1:]
+	}
+	return "", 0, 0, false
+}
+
+// optionalTypeName returns the name of the type if it is a type defined in
+// package optional.
+func optionalTypeName(t types.Type) (string, bool) {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return "", false
+	}
+	obj := named.Origin().Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != optionalPath {
+		return "", false
+	}
+	return obj.Name(), true
+}
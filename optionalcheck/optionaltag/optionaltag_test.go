@@ -0,0 +1,12 @@
+package optionaltag_test
+
+import (
+	"testing"
+
+	"4d63.com/optional/optionalcheck/optionaltag"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func Test(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), optionaltag.Analyzer, "a")
+}
@@ -0,0 +1,27 @@
+// Package optional is a stub of package 4d63.com/optional for tests.
+package optional
+
+type Optional[T any] struct {
+	value   T
+	present bool
+}
+
+func Of[T any](value T) Optional[T]           { return Optional[T]{value: value, present: true} }
+func Empty[T any]() Optional[T]               { return Optional[T]{} }
+func (o Optional[T]) Get() (value T, ok bool) { return o.value, o.present }
+func (o Optional[T]) ElseZero() T             { return o.value }
+
+type Field[T any] struct {
+	value   Optional[T]
+	defined bool
+}
+
+func Undefined[T any]() Field[T]           { return Field[T]{} }
+func (f Field[T]) Get() (value T, ok bool) { return f.value.Get() }
+
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+func (r Result[T]) Get() (value T, ok bool) { return r.value, r.err == nil }
@@ -0,0 +1,24 @@
+package a
+
+import (
+	"time"
+
+	"4d63.com/optional"
+)
+
+type Request struct {
+	Missing   optional.Optional[int]       `json:"missing"`              // want `json tag of optional.Optional field does not have omitzero; empty values are marshaled as null`
+	OmitEmpty optional.Optional[string]    `json:"omit_empty,omitempty"` // want `json tag of optional.Optional field has omitempty, which does not omit empty values; use omitzero`
+	Both      optional.Optional[int]       `json:"both,omitempty,omitzero"`
+	OmitZero  optional.Optional[int]       `json:"omit_zero,omitzero"`
+	String    optional.Optional[int]       `json:"string,string"`                  // want `json tag of optional.Optional field does not have omitzero`
+	NoName    optional.Optional[int]       `json:",omitempty"`                     // want `json tag of optional.Optional field has omitempty`
+	Other     optional.Optional[int]       `xml:"other" json:"other" yaml:"other"` // want `json tag of optional.Optional field does not have omitzero`
+	XML       optional.Optional[int]       `xml:"xml"`
+	Skip      optional.Optional[int]       `json:"-"`
+	Field     optional.Field[int]          `json:"field"` // want `json tag of optional.Field field does not have omitzero`
+	Result    optional.Result[int]         `json:"result"`
+	Time      optional.Optional[time.Time] "json:\"time\"" // want `json tag of optional.Optional field does not have omitzero`
+	NoTag     optional.Optional[int]
+	Int       int `json:"int"`
+}
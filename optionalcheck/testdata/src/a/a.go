@@ -0,0 +1,52 @@
+package a
+
+import "4d63.com/optional"
+
+type record struct {
+	ID optional.Optional[int]
+}
+
+type alias = optional.Optional[string]
+
+func compositeLiterals() {
+	_ = optional.Optional[int]{}     // want `composite literal of optional.Optional; use optional.Empty or optional.Of`
+	_ = optional.Field[int]{}        // want `composite literal of optional.Field; use optional.Undefined, optional.Null or optional.FieldOf`
+	_ = alias{}                      // want `composite literal of optional.Optional; use optional.Empty or optional.Of`
+	_ = []optional.Optional[int]{{}} // want `composite literal of optional.Optional; use optional.Empty or optional.Of`
+	_ = optional.Result[int]{}
+	_ = record{}
+	_ = record{ID: optional.Empty[int]()}
+	_ = optional.Of(1)
+}
+
+func discardedOk(o optional.Optional[int], f optional.Field[int], r optional.Result[int]) {
+	v1, _ := o.Get() // want `ok result of optional.Optional.Get is discarded but the value is used; check ok, or use ElseZero to use the zero value when empty`
+	_ = v1
+
+	var v2 int
+	v2, _ = f.Get() // want `ok result of optional.Field.Get is discarded`
+	_ = v2
+
+	var v3, _ = r.Get() // want `ok result of optional.Result.Get is discarded`
+	_ = v3
+
+	v4, _ := (o.Get()) // want `ok result of optional.Optional.Get is discarded`
+	_ = v4
+
+	_, _ = o.Get()
+
+	if v, ok := o.Get(); ok {
+		_ = v
+	}
+
+	_ = o.ElseZero()
+}
+
+type other struct{}
+
+func (other) Get() (int, bool) { return 0, false }
+
+func otherGet(o other) {
+	v, _ := o.Get()
+	_ = v
+}
@@ -0,0 +1,53 @@
+package optional
+
+import (
+	"encoding/json"
+	"encoding/xml"
+)
+
+// OrZero is an Optional that is marshaled to JSON and XML as the zero value of
+// its type when it is empty, instead of as null or being omitted, and that
+// unmarshals JSON null as an optional wrapping the zero value. It keeps the
+// encoding that optionals had before null support was added and before they
+// were structs, for documents and consumers that rely on it.
+//
+// All other methods, including IsZero, are those of the Optional it embeds, so
+// an empty OrZero is still omitted by encoding/json from fields with the
+// omitzero option:
+//
+//	s := struct {
+//		Count optional.OrZero[int] `json:"count"`
+//	}{
+//		Count: optional.OrZero[int]{Optional: optional.Empty[int]()},
+//	}
+//
+//	output, _ := json.Marshal(s)
+//
+//	// output = {"count":0}
+type OrZero[T any] struct {
+	Optional[T]
+}
+
+// MarshalJSON marshals the value being wrapped to JSON. If there is no value
+// being wrapped, the zero value of its type is marshaled.
+func (o OrZero[T]) MarshalJSON() (data []byte, err error) {
+	return json.Marshal(o.ElseZero())
+}
+
+// UnmarshalJSON unmarshals the JSON into a value wrapped by this optional. If
+// the JSON is null the optional wraps the zero value of its type.
+func (o *OrZero[T]) UnmarshalJSON(data []byte) error {
+	var v T
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+	o.Optional = Of(v)
+	return nil
+}
+
+// MarshalXML marshals the value being wrapped to XML. If there is no value
+// being wrapped, the zero value of its type is marshaled.
+func (o OrZero[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(o.ElseZero(), start)
+}
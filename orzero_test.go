@@ -0,0 +1,86 @@
+package optional
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+)
+
+func TestOrZeroMarshalJSON(t *testing.T) {
+	tests := []struct {
+		OrZero          OrZero[int]
+		ExpectedMarshal string
+	}{
+		{OrZero[int]{Optional: Empty[int]()}, `0`},
+		{OrZero[int]{Optional: Of(0)}, `0`},
+		{OrZero[int]{Optional: Of(1)}, `1`},
+	}
+
+	for _, test := range tests {
+		data, err := test.OrZero.MarshalJSON()
+
+		if err != nil || string(data) != test.ExpectedMarshal {
+			t.Errorf("%#v MarshalJSON got %#v, %v, want %#v", test.OrZero, string(data), err, test.ExpectedMarshal)
+		}
+	}
+}
+
+func TestOrZeroUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		JSON              string
+		ExpectedValue     int
+		ExpectedIsPresent bool
+	}{
+		{`null`, 0, true},
+		{`0`, 0, true},
+		{`1`, 1, true},
+	}
+
+	for _, test := range tests {
+		o := OrZero[int]{Optional: Of(100)}
+		err := o.UnmarshalJSON([]byte(test.JSON))
+		value, ok := o.Get()
+
+		if err != nil || value != test.ExpectedValue || ok != test.ExpectedIsPresent {
+			t.Errorf("%#v UnmarshalJSON got %#v, %#v, %v, want %#v, %#v", test.JSON, value, ok, err, test.ExpectedValue, test.ExpectedIsPresent)
+		}
+	}
+}
+
+func TestOrZeroJSONFields(t *testing.T) {
+	s := struct {
+		Zero    OrZero[int] `json:"zero"`
+		Omitted OrZero[int] `json:"omitted,omitzero"`
+		Present OrZero[int] `json:"present,omitzero"`
+	}{
+		Present: OrZero[int]{Optional: Of(1)},
+	}
+
+	data, err := json.Marshal(s)
+	expected := `{"zero":0,"present":1}`
+	if err != nil || string(data) != expected {
+		t.Errorf("Marshal got %s, %v, want %s", data, err, expected)
+	}
+}
+
+func TestOrZeroMarshalXML(t *testing.T) {
+	tests := []struct {
+		OrZero          OrZero[int]
+		ExpectedMarshal string
+	}{
+		{OrZero[int]{Optional: Empty[int]()}, `<s><i>0</i></s>`},
+		{OrZero[int]{Optional: Of(0)}, `<s><i>0</i></s>`},
+		{OrZero[int]{Optional: Of(1)}, `<s><i>1</i></s>`},
+	}
+
+	for _, test := range tests {
+		data, err := xml.Marshal(struct {
+			XMLName xml.Name    `xml:"s"`
+			I       OrZero[int] `xml:"i"`
+		}{I: test.OrZero})
+
+		if err != nil || string(data) != test.ExpectedMarshal {
+			t.Errorf("%#v MarshalXML got %#v, %v, want %#v", test.OrZero, string(data), err, test.ExpectedMarshal)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package optional
+
+// OfPtrSlice returns a slice of optionals wrapping the values pointed to by the
+// pointers in the slice, with an empty optional for each nil pointer.
+func OfPtrSlice[T any](ptrs []*T) []Optional[T] {
+	if ptrs == nil {
+		return nil
+	}
+	s := make([]Optional[T], len(ptrs))
+	for i, p := range ptrs {
+		s[i] = OfPtr(p)
+	}
+	return s
+}
+
+// PtrSlice returns a slice of pointers to copies of the values wrapped by the
+// optionals in the slice, with a nil pointer for each empty optional.
+func PtrSlice[T any](optionals []Optional[T]) []*T {
+	if optionals == nil {
+		return nil
+	}
+	s := make([]*T, len(optionals))
+	for i, o := range optionals {
+		s[i] = o.Ptr()
+	}
+	return s
+}
+
+// OfPtrMap returns a map of optionals wrapping the values pointed to by the
+// pointers in the map, with an empty optional for each nil pointer.
+func OfPtrMap[K comparable, T any](ptrs map[K]*T) map[K]Optional[T] {
+	if ptrs == nil {
+		return nil
+	}
+	m := make(map[K]Optional[T], len(ptrs))
+	for k, p := range ptrs {
+		m[k] = OfPtr(p)
+	}
+	return m
+}
+
+// PtrMap returns a map of pointers to copies of the values wrapped by the
+// optionals in the map, with a nil pointer for each empty optional.
+func PtrMap[K comparable, T any](optionals map[K]Optional[T]) map[K]*T {
+	if optionals == nil {
+		return nil
+	}
+	m := make(map[K]*T, len(optionals))
+	for k, o := range optionals {
+		m[k] = o.Ptr()
+	}
+	return m
+}
@@ -0,0 +1,69 @@
+package optional
+
+import "testing"
+
+func TestPtrSlice(t *testing.T) {
+	a, b := "a", ""
+	ptrs := []*string{&a, nil, &b}
+
+	optionals := OfPtrSlice(ptrs)
+	if len(optionals) != len(ptrs) {
+		t.Fatalf("OfPtrSlice got len %d, want %d", len(optionals), len(ptrs))
+	}
+	for i, p := range ptrs {
+		v, ok := optionals[i].Get()
+		if ok != (p != nil) || (p != nil && v != *p) {
+			t.Errorf("OfPtrSlice[%d] got %#v, %#v, want %#v", i, v, ok, p)
+		}
+	}
+
+	back := PtrSlice(optionals)
+	if len(back) != len(ptrs) {
+		t.Fatalf("PtrSlice got len %d, want %d", len(back), len(ptrs))
+	}
+	for i, p := range ptrs {
+		if (back[i] == nil) != (p == nil) || (p != nil && (*back[i] != *p || back[i] == p)) {
+			t.Errorf("PtrSlice[%d] got %#v, want a copy of %#v", i, back[i], p)
+		}
+	}
+
+	if OfPtrSlice[string](nil) != nil {
+		t.Errorf("OfPtrSlice(nil) got non-nil, want nil")
+	}
+	if PtrSlice[string](nil) != nil {
+		t.Errorf("PtrSlice(nil) got non-nil, want nil")
+	}
+}
+
+func TestPtrMap(t *testing.T) {
+	a, b := 1, 0
+	ptrs := map[string]*int{"a": &a, "nil": nil, "b": &b}
+
+	optionals := OfPtrMap(ptrs)
+	if len(optionals) != len(ptrs) {
+		t.Fatalf("OfPtrMap got len %d, want %d", len(optionals), len(ptrs))
+	}
+	for k, p := range ptrs {
+		v, ok := optionals[k].Get()
+		if ok != (p != nil) || (p != nil && v != *p) {
+			t.Errorf("OfPtrMap[%q] got %#v, %#v, want %#v", k, v, ok, p)
+		}
+	}
+
+	back := PtrMap(optionals)
+	if len(back) != len(ptrs) {
+		t.Fatalf("PtrMap got len %d, want %d", len(back), len(ptrs))
+	}
+	for k, p := range ptrs {
+		if (back[k] == nil) != (p == nil) || (p != nil && (*back[k] != *p || back[k] == p)) {
+			t.Errorf("PtrMap[%q] got %#v, want a copy of %#v", k, back[k], p)
+		}
+	}
+
+	if OfPtrMap[string, int](nil) != nil {
+		t.Errorf("OfPtrMap(nil) got non-nil, want nil")
+	}
+	if PtrMap[string, int](nil) != nil {
+		t.Errorf("PtrMap(nil) got non-nil, want nil")
+	}
+}
@@ -0,0 +1,139 @@
+package optional
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ErrEmpty is the error of a result that was unmarshaled from a JSON null, or
+// converted from an empty optional without an error.
+var ErrEmpty = errors.New("optional: empty")
+
+// Result holds either a value, or an error. It is intended for converting a
+// function's value and error results into a single value without losing the
+// error, like Optional does.
+//
+// The zero value of a Result holds the zero value of its type and no error.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// ResultOf returns a result holding the error if it is not nil, otherwise the
+// value.
+func ResultOf[T any](value T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(value)
+}
+
+// Ok returns a result holding the value.
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err returns a result holding the error. If the error is nil the result holds
+// the zero value of its type.
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// Result returns a result holding the value wrapped by this optional, or the
+// error if there is no value wrapped. If there is no value wrapped and the
+// error is nil, the result holds ErrEmpty.
+func (o Optional[T]) Result(err error) Result[T] {
+	if v, ok := o.Get(); ok {
+		return Ok(v)
+	}
+	if err == nil {
+		err = ErrEmpty
+	}
+	return Err[T](err)
+}
+
+// Err returns the error held by this result, or nil if it holds a value.
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// IsOk returns true if this result holds a value and not an error.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Get returns the value held by this result, and an ok signal for whether the
+// result holds a value and not an error.
+func (r Result[T]) Get() (value T, ok bool) {
+	return r.Optional().Get()
+}
+
+// Optional returns an optional wrapping the value held by this result, or an
+// empty optional if this result holds an error.
+func (r Result[T]) Optional() Optional[T] {
+	if r.IsOk() {
+		return Of(r.value)
+	}
+	return Empty[T]()
+}
+
+// If calls the function if this result holds a value.
+func (r Result[T]) If(f func(value T)) {
+	r.Optional().If(f)
+}
+
+// ElseFunc returns the value held by this result, or the value returned by the
+// function if this result holds an error.
+func (r Result[T]) ElseFunc(f func() T) (value T) {
+	return r.Optional().ElseFunc(f)
+}
+
+// Else returns the value held by this result, or the value passed in if this
+// result holds an error.
+func (r Result[T]) Else(elseValue T) (value T) {
+	return r.Optional().Else(elseValue)
+}
+
+// ElseZero returns the value held by this result, or the zero value of its
+// type if this result holds an error.
+func (r Result[T]) ElseZero() (value T) {
+	return r.Optional().ElseZero()
+}
+
+// MapResult returns a result holding the value returned by calling the
+// function with the value held by the result, or the result's error. The
+// function is only called if the result holds a value.
+func MapResult[T, U any](r Result[T], f func(value T) U) Result[U] {
+	if v, ok := r.Get(); ok {
+		return Ok(f(v))
+	}
+	return Err[U](r.Err())
+}
+
+// FlatMapResult returns the result returned by calling the function with the
+// value held by the result, or the result's error. The function is only called
+// if the result holds a value.
+func FlatMapResult[T, U any](r Result[T], f func(value T) Result[U]) Result[U] {
+	if v, ok := r.Get(); ok {
+		return f(v)
+	}
+	return Err[U](r.Err())
+}
+
+// MarshalJSON marshals the value held by this result to JSON. If this result
+// holds an error, it is marshaled the same as an empty optional.
+func (r Result[T]) MarshalJSON() (data []byte, err error) {
+	return r.Optional().MarshalJSON()
+}
+
+// UnmarshalJSON unmarshals the JSON into a value held by this result. JSON
+// that would unmarshal into an empty optional results in ErrEmpty.
+func (r *Result[T]) UnmarshalJSON(data []byte) error {
+	var o Optional[T]
+	err := json.Unmarshal(data, &o)
+	if err != nil {
+		return err
+	}
+	*r = o.Result(ErrEmpty)
+	return nil
+}
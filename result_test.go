@@ -0,0 +1,198 @@
+package optional
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+var errTest = errors.New("test error")
+
+func TestResultOf(t *testing.T) {
+	tests := []struct {
+		Result        Result[string]
+		ExpectedValue string
+		ExpectedOk    bool
+		ExpectedErr   error
+	}{
+		{ResultOf("", nil), "", true, nil},
+		{ResultOf("string", nil), "string", true, nil},
+		{ResultOf("string", errTest), "", false, errTest},
+		{Ok(""), "", true, nil},
+		{Ok("string"), "string", true, nil},
+		{Err[string](errTest), "", false, errTest},
+		{Err[string](nil), "", true, nil},
+		{Result[string]{}, "", true, nil},
+		{Of("string").Result(errTest), "string", true, nil},
+		{Empty[string]().Result(errTest), "", false, errTest},
+		{Of("string").Result(nil), "string", true, nil},
+		{Empty[string]().Result(nil), "", false, ErrEmpty},
+	}
+
+	for _, test := range tests {
+		value, ok := test.Result.Get()
+		err := test.Result.Err()
+
+		if value != test.ExpectedValue || ok != test.ExpectedOk || ok != test.Result.IsOk() || err != test.ExpectedErr {
+			t.Errorf("%#v Get, Err got %#v, %#v, %#v, want %#v, %#v, %#v", test.Result, value, ok, err, test.ExpectedValue, test.ExpectedOk, test.ExpectedErr)
+		}
+	}
+}
+
+func TestResultElse(t *testing.T) {
+	const orElse = "orelse"
+	tests := []struct {
+		Result         Result[string]
+		ExpectedResult string
+		ExpectedZero   string
+	}{
+		{Ok(""), "", ""},
+		{Ok("string"), "string", "string"},
+		{Err[string](errTest), orElse, ""},
+	}
+
+	for _, test := range tests {
+		result := test.Result.Else(orElse)
+		resultFunc := test.Result.ElseFunc(func() string { return orElse })
+		zero := test.Result.ElseZero()
+
+		if result != test.ExpectedResult || resultFunc != test.ExpectedResult || zero != test.ExpectedZero {
+			t.Errorf("%#v Else, ElseFunc, ElseZero got %#v, %#v, %#v, want %#v, %#v, %#v", test.Result, result, resultFunc, zero, test.ExpectedResult, test.ExpectedResult, test.ExpectedZero)
+		}
+	}
+}
+
+func TestResultIf(t *testing.T) {
+	tests := []struct {
+		Result         Result[string]
+		ExpectedCalled bool
+	}{
+		{Ok("string"), true},
+		{Err[string](errTest), false},
+	}
+
+	for _, test := range tests {
+		called := false
+		test.Result.If(func(string) { called = true })
+
+		if called != test.ExpectedCalled {
+			t.Errorf("%#v If called %#v, want %#v", test.Result, called, test.ExpectedCalled)
+		}
+	}
+}
+
+func TestResultOptional(t *testing.T) {
+	tests := []struct {
+		Result            Result[string]
+		ExpectedValue     string
+		ExpectedIsPresent bool
+	}{
+		{Ok(""), "", true},
+		{Ok("string"), "string", true},
+		{Err[string](errTest), "", false},
+	}
+
+	for _, test := range tests {
+		value, ok := test.Result.Optional().Get()
+
+		if value != test.ExpectedValue || ok != test.ExpectedIsPresent {
+			t.Errorf("%#v Optional got %#v, %#v, want %#v, %#v", test.Result, value, ok, test.ExpectedValue, test.ExpectedIsPresent)
+		}
+	}
+}
+
+func TestMapResult(t *testing.T) {
+	tests := []struct {
+		Result         Result[int]
+		ExpectedCalled bool
+		ExpectedValue  string
+		ExpectedErr    error
+	}{
+		{Ok(1), true, "1", nil},
+		{Err[int](errTest), false, "", errTest},
+	}
+
+	for _, test := range tests {
+		called := false
+		r := MapResult(test.Result, func(i int) string {
+			called = true
+			return strconv.Itoa(i)
+		})
+
+		if called != test.ExpectedCalled || r.ElseZero() != test.ExpectedValue || r.Err() != test.ExpectedErr {
+			t.Errorf("%#v MapResult got %#v, %#v, want %#v, %#v, %#v", test.Result, called, r, test.ExpectedCalled, test.ExpectedValue, test.ExpectedErr)
+		}
+	}
+}
+
+func TestFlatMapResult(t *testing.T) {
+	tests := []struct {
+		Result         Result[string]
+		ExpectedCalled bool
+		ExpectedValue  int
+		ExpectedOk     bool
+	}{
+		{Ok("1"), true, 1, true},
+		{Ok("x"), true, 0, false},
+		{Err[string](errTest), false, 0, false},
+	}
+
+	for _, test := range tests {
+		called := false
+		r := FlatMapResult(test.Result, func(s string) Result[int] {
+			called = true
+			return ResultOf(strconv.Atoi(s))
+		})
+		value, ok := r.Get()
+
+		if called != test.ExpectedCalled || value != test.ExpectedValue || ok != test.ExpectedOk {
+			t.Errorf("%#v FlatMapResult got %#v, %#v, %#v, want %#v, %#v, %#v", test.Result, called, value, ok, test.ExpectedCalled, test.ExpectedValue, test.ExpectedOk)
+		}
+	}
+}
+
+func TestResultMarshalJSON(t *testing.T) {
+	tests := []struct {
+		Result          Result[int]
+		ExpectedMarshal string
+	}{
+		{Ok(0), `0`},
+		{Ok(1), `1`},
+		{Err[int](errTest), `null`},
+	}
+
+	for _, test := range tests {
+		data, err := test.Result.MarshalJSON()
+
+		if err != nil || string(data) != test.ExpectedMarshal {
+			t.Errorf("%#v MarshalJSON got %#v, %v, want %#v", test.Result, string(data), err, test.ExpectedMarshal)
+		}
+	}
+}
+
+func TestResultUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		JSON          string
+		ExpectedValue int
+		ExpectedErr   error
+	}{
+		{`null`, 0, ErrEmpty},
+		{`0`, 0, nil},
+		{`1`, 1, nil},
+	}
+
+	for _, test := range tests {
+		r := Ok(100)
+		err := r.UnmarshalJSON([]byte(test.JSON))
+
+		if err != nil || r.ElseZero() != test.ExpectedValue || r.Err() != test.ExpectedErr {
+			t.Errorf("%#v UnmarshalJSON got %#v, %v, want %#v, %#v", test.JSON, r, err, test.ExpectedValue, test.ExpectedErr)
+		}
+	}
+
+	r := Ok(100)
+	err := r.UnmarshalJSON([]byte(`"string"`))
+	if err == nil {
+		t.Errorf("UnmarshalJSON of string into int got nil error, want error")
+	}
+}
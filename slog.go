@@ -0,0 +1,26 @@
+//go:build go1.21
+
+package optional
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer. It returns the value wrapped, which
+// handlers resolve further if the value wrapped implements slog.LogValuer
+// itself, or null if there is no value wrapped.
+func (o Optional[T]) LogValue() slog.Value {
+	return o.LogValueOr(slog.AnyValue(nil))
+}
+
+// LogValueOr returns the value wrapped as a slog.Value, like LogValue, or the
+// value passed in if there is no value wrapped. It sets how an empty optional
+// is logged at the call site. Pass slog.GroupValue() to drop the attribute, as
+// handlers ignore empty groups, or another value such as
+// slog.StringValue("<empty>") to log a sentinel:
+//
+//	logger.Info("signup", "age", age.LogValueOr(slog.GroupValue()))
+func (o Optional[T]) LogValueOr(empty slog.Value) slog.Value {
+	if v, ok := o.Get(); ok {
+		return slog.AnyValue(v)
+	}
+	return empty
+}
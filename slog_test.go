@@ -0,0 +1,96 @@
+//go:build go1.21
+
+package optional
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+type secret string
+
+func (s secret) LogValue() slog.Value {
+	return slog.StringValue("***")
+}
+
+func TestLogValue(t *testing.T) {
+	tests := []struct {
+		Name         string
+		Args         []any
+		ExpectedText string
+		ExpectedJSON string
+	}{
+		{
+			"present",
+			[]any{"a", Of(0), "b", Of("x")},
+			`a=0 b=x`,
+			`"a":0,"b":"x"`,
+		},
+		{
+			"present log valuer",
+			[]any{"a", Of(secret("password"))},
+			`a=***`,
+			`"a":"***"`,
+		},
+		{
+			"present optional",
+			[]any{"a", Of(Of(1))},
+			`a=1`,
+			`"a":1`,
+		},
+		{
+			"empty null",
+			[]any{"a", Empty[int](), "b", 1},
+			`a=<nil> b=1`,
+			`"a":null,"b":1`,
+		},
+		{
+			"empty drop",
+			[]any{"a", Empty[int]().LogValueOr(slog.GroupValue()), "b", 1},
+			`b=1`,
+			`"b":1`,
+		},
+		{
+			"empty sentinel",
+			[]any{"a", Empty[int]().LogValueOr(slog.StringValue("<empty>")), "b", 1},
+			`a=<empty> b=1`,
+			`"a":"<empty>","b":1`,
+		},
+		{
+			"present or",
+			[]any{"a", Of(1).LogValueOr(slog.StringValue("<empty>")), "b", Of(secret("password")).LogValueOr(slog.GroupValue())},
+			`a=1 b=***`,
+			`"a":1,"b":"***"`,
+		},
+		{
+			"group",
+			[]any{slog.Group("g", "a", Empty[int]().LogValueOr(slog.GroupValue()), "b", Of(1))},
+			`g.b=1`,
+			`"g":{"b":1}`,
+		},
+	}
+
+	removeBuiltins := func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+			return slog.Attr{}
+		}
+		return a
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			text := bytes.Buffer{}
+			slog.New(slog.NewTextHandler(&text, &slog.HandlerOptions{ReplaceAttr: removeBuiltins})).Info("", test.Args...)
+			if got, want := text.String(), test.ExpectedText+"\n"; got != want {
+				t.Errorf("text got %q, want %q", got, want)
+			}
+
+			json := bytes.Buffer{}
+			slog.New(slog.NewJSONHandler(&json, &slog.HandlerOptions{ReplaceAttr: removeBuiltins})).Info("", test.Args...)
+			if got, want := json.String(), "{"+test.ExpectedJSON+"}\n"; got != want {
+				t.Errorf("json got %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+//go:build go1.22
+
+package optional
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// Scan implements sql.Scanner. A NULL value is scanned as an empty optional,
+// and any other value is converted into the type wrapped using the same rules
+// as database/sql uses when scanning into a value of that type, including
+// calling Scan if the type implements sql.Scanner.
+func (o *Optional[T]) Scan(src any) error {
+	var n sql.Null[T]
+	err := n.Scan(src)
+	if err != nil {
+		return err
+	}
+	if n.Valid {
+		*o = Of(n.V)
+	} else {
+		*o = Empty[T]()
+	}
+	return nil
+}
+
+// Value implements driver.Valuer. An empty optional is written as NULL, and
+// the value wrapped is converted using the driver's default parameter
+// conversion, including calling Value if the type implements driver.Valuer.
+func (o Optional[T]) Value() (driver.Value, error) {
+	v, ok := o.Get()
+	if !ok {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
@@ -0,0 +1,177 @@
+//go:build go1.22
+
+package optional
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeConnector is a database/sql/driver.Connector for an in-memory database
+// with a single table with a single column. Every Exec inserts its argument as
+// a row, and every Query returns all rows.
+type fakeConnector struct {
+	rows []driver.Value
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{c}, nil }
+func (c *fakeConnector) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ c *fakeConnector }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt(c), nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+type fakeStmt struct{ c *fakeConnector }
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.c.rows = append(s.c.rows, args[0])
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{rows: s.c.rows}, nil
+}
+
+type fakeRows struct{ rows []driver.Value }
+
+func (r *fakeRows) Columns() []string { return []string{"value"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	dest[0], r.rows = r.rows[0], r.rows[1:]
+	return nil
+}
+
+// upper is a type that implements sql.Scanner and driver.Valuer, storing its
+// value in lower case and scanning it in upper case.
+type upper string
+
+func (u *upper) Scan(src any) error {
+	s, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into upper", src)
+	}
+	*u = upper(strings.ToUpper(s))
+	return nil
+}
+
+func (u upper) Value() (driver.Value, error) {
+	return strings.ToLower(string(u)), nil
+}
+
+func roundTrip[T any](t *testing.T, values ...Optional[T]) (stored []driver.Value, scanned []Optional[T]) {
+	t.Helper()
+	c := &fakeConnector{}
+	db := sql.OpenDB(c)
+	defer db.Close()
+	for _, v := range values {
+		_, err := db.Exec("INSERT", v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var o Optional[T]
+		err := rows.Scan(&o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanned = append(scanned, o)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return c.rows, scanned
+}
+
+func TestSQLInt(t *testing.T) {
+	stored, scanned := roundTrip(t, Empty[int](), Of(0), Of(1))
+
+	wantStored := []driver.Value{nil, int64(0), int64(1)}
+	wantScanned := []Optional[int]{Empty[int](), Of(0), Of(1)}
+	for i := range wantStored {
+		if stored[i] != wantStored[i] {
+			t.Errorf("stored[%d] got %#v, want %#v", i, stored[i], wantStored[i])
+		}
+		if !Equal(scanned[i], wantScanned[i]) {
+			t.Errorf("scanned[%d] got %#v, want %#v", i, scanned[i], wantScanned[i])
+		}
+	}
+}
+
+func TestSQLString(t *testing.T) {
+	stored, scanned := roundTrip(t, Empty[string](), Of(""), Of("string"))
+
+	wantStored := []driver.Value{nil, "", "string"}
+	wantScanned := []Optional[string]{Empty[string](), Of(""), Of("string")}
+	for i := range wantStored {
+		if stored[i] != wantStored[i] {
+			t.Errorf("stored[%d] got %#v, want %#v", i, stored[i], wantStored[i])
+		}
+		if !Equal(scanned[i], wantScanned[i]) {
+			t.Errorf("scanned[%d] got %#v, want %#v", i, scanned[i], wantScanned[i])
+		}
+	}
+}
+
+func TestSQLTime(t *testing.T) {
+	tm := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	stored, scanned := roundTrip(t, Empty[time.Time](), Of(tm))
+
+	wantStored := []driver.Value{nil, tm}
+	wantScanned := []Optional[time.Time]{Empty[time.Time](), Of(tm)}
+	for i := range wantStored {
+		if stored[i] != wantStored[i] {
+			t.Errorf("stored[%d] got %#v, want %#v", i, stored[i], wantStored[i])
+		}
+		if !Equal(scanned[i], wantScanned[i]) {
+			t.Errorf("scanned[%d] got %#v, want %#v", i, scanned[i], wantScanned[i])
+		}
+	}
+}
+
+func TestSQLScannerValuer(t *testing.T) {
+	stored, scanned := roundTrip(t, Empty[upper](), Of(upper("String")))
+
+	wantStored := []driver.Value{nil, "string"}
+	wantScanned := []Optional[upper]{Empty[upper](), Of(upper("STRING"))}
+	for i := range wantStored {
+		if stored[i] != wantStored[i] {
+			t.Errorf("stored[%d] got %#v, want %#v", i, stored[i], wantStored[i])
+		}
+		if !Equal(scanned[i], wantScanned[i]) {
+			t.Errorf("scanned[%d] got %#v, want %#v", i, scanned[i], wantScanned[i])
+		}
+	}
+}
+
+func TestSQLScanConversionError(t *testing.T) {
+	o := Of(100)
+	err := o.Scan("not a number")
+
+	if err == nil {
+		t.Errorf("Scan got nil error, want error")
+	}
+	if v, ok := o.Get(); !ok || v != 100 {
+		t.Errorf("Scan modified optional on error, got %#v", o)
+	}
+}
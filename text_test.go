@@ -0,0 +1,172 @@
+package optional
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+type level int
+
+func TestMarshalText(t *testing.T) {
+	tests := []struct {
+		Optional     interface{ MarshalText() ([]byte, error) }
+		ExpectedText string
+	}{
+		{Empty[int](), ""},
+		{Empty[string](), ""},
+		{Empty[time.Time](), ""},
+		{Of(""), ""},
+		{Of("string"), "string"},
+		{Of(true), "true"},
+		{Of(false), "false"},
+		{Of(-1), "-1"},
+		{Of[int8](-8), "-8"},
+		{Of[int64](-64), "-64"},
+		{Of[uint](1), "1"},
+		{Of[uint8](8), "8"},
+		{Of[uint64](64), "64"},
+		{Of[uintptr](12), "12"},
+		{Of[float32](2.1), "2.1"},
+		{Of(2.2), "2.2"},
+		{Of(complex(1, 2)), "(1+2i)"},
+		{Of(level(3)), "3"},
+		{Of(30 * time.Second), "30s"},
+		{Of(time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)), "2006-01-02T15:04:05Z"},
+		{Of(big.NewInt(100)), "100"},
+		{Of(new(int)), "0"},
+	}
+
+	for _, test := range tests {
+		text, err := test.Optional.MarshalText()
+
+		if err != nil || string(text) != test.ExpectedText {
+			t.Errorf("%#v MarshalText got %#v, %v, want %#v", test.Optional, string(text), err, test.ExpectedText)
+		}
+	}
+}
+
+func TestMarshalTextUnsupported(t *testing.T) {
+	_, err := Of([]int{1}).MarshalText()
+
+	if err == nil {
+		t.Errorf("MarshalText got nil error, want error")
+	}
+}
+
+func TestMarshalTextMapKeyCollision(t *testing.T) {
+	m := map[Optional[string]]int{Empty[string](): 2, Of(""): 3, Of("a"): 4}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(data), `"":`); got != 2 {
+		t.Errorf("Marshal got %s, want the key \"\" twice", data)
+	}
+
+	var u map[Optional[string]]int
+	err = json.Unmarshal(data, &u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := u[Empty[string]()]; len(u) != 2 || !ok {
+		t.Errorf("Unmarshal got %#v, want the colliding keys as one empty optional", u)
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	tests := []struct {
+		Text     string
+		Optional interface {
+			UnmarshalText([]byte) error
+		}
+		ExpectedString    string
+		ExpectedIsPresent bool
+	}{
+		{"", &Optional[int]{}, "0", false},
+		{"", &Optional[string]{}, "", false},
+		{"", &Optional[time.Time]{}, "0001-01-01 00:00:00 +0000 UTC", false},
+		{"string", &Optional[string]{}, "string", true},
+		{"true", &Optional[bool]{}, "true", true},
+		{"-1", &Optional[int]{}, "-1", true},
+		{"-8", &Optional[int8]{}, "-8", true},
+		{"8", &Optional[uint8]{}, "8", true},
+		{"12", &Optional[uintptr]{}, "12", true},
+		{"2.1", &Optional[float32]{}, "2.1", true},
+		{"(1+2i)", &Optional[complex128]{}, "(1+2i)", true},
+		{"3", &Optional[level]{}, "3", true},
+		{"30s", &Optional[time.Duration]{}, "30s", true},
+		{"2006-01-02T15:04:05Z", &Optional[time.Time]{}, "2006-01-02 15:04:05 +0000 UTC", true},
+		{"100", &Optional[*big.Int]{}, "100", true},
+	}
+
+	for _, test := range tests {
+		err := test.Optional.UnmarshalText([]byte(test.Text))
+		o := test.Optional.(interface {
+			IsPresent() bool
+			String() string
+		})
+
+		if err != nil || o.String() != test.ExpectedString || o.IsPresent() != test.ExpectedIsPresent {
+			t.Errorf("%#v UnmarshalText got %#v, %#v, %v, want %#v, %#v", test.Text, o.String(), o.IsPresent(), err, test.ExpectedString, test.ExpectedIsPresent)
+		}
+	}
+}
+
+func TestUnmarshalTextError(t *testing.T) {
+	tests := []struct {
+		Text     string
+		Optional interface {
+			UnmarshalText([]byte) error
+		}
+	}{
+		{"string", &Optional[int]{}},
+		{"256", &Optional[uint8]{}},
+		{"-1", &Optional[uint]{}},
+		{"yes please", &Optional[bool]{}},
+		{"1.x", &Optional[float64]{}},
+		{"30", &Optional[time.Duration]{}},
+		{"2006", &Optional[time.Time]{}},
+		{"1", &Optional[[]int]{}},
+	}
+
+	for _, test := range tests {
+		err := test.Optional.UnmarshalText([]byte(test.Text))
+
+		if err == nil {
+			t.Errorf("%#v UnmarshalText into %T got nil error, want error", test.Text, test.Optional)
+		}
+	}
+}
+
+func TestSetText(t *testing.T) {
+	tests := []struct {
+		Text     string
+		Optional interface {
+			setText(string) error
+			String() string
+			IsPresent() bool
+		}
+		ExpectedString    string
+		ExpectedIsPresent bool
+		ExpectedErr       bool
+	}{
+		{"", &Optional[string]{}, "", true, false},
+		{"a", &Optional[string]{}, "a", true, false},
+		{"1", &Optional[int]{}, "1", true, false},
+		{"", &Optional[int]{}, "0", false, true},
+		{"30s", &Optional[time.Duration]{}, "30s", true, false},
+		{"x", &Optional[int]{}, "0", false, true},
+	}
+
+	for _, test := range tests {
+		err := test.Optional.setText(test.Text)
+
+		if test.Optional.String() != test.ExpectedString || test.Optional.IsPresent() != test.ExpectedIsPresent || (err != nil) != test.ExpectedErr {
+			t.Errorf("%#v setText got %#v, %#v, %v, want %#v, %#v, err %v", test.Text, test.Optional.String(), test.Optional.IsPresent(), err, test.ExpectedString, test.ExpectedIsPresent, test.ExpectedErr)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package optional
+
+// Map returns an optional wrapping the result of calling the function with the
+// value wrapped by the optional, or an empty optional if the optional is empty.
+// The function is only called if there is a value wrapped.
+func Map[T, U any](o Optional[T], f func(value T) U) Optional[U] {
+	if v, ok := o.Get(); ok {
+		return Of(f(v))
+	}
+	return Empty[U]()
+}
+
+// FlatMap returns the optional returned by calling the function with the value
+// wrapped by the optional, or an empty optional if the optional is empty. The
+// function is only called if there is a value wrapped.
+func FlatMap[T, U any](o Optional[T], f func(value T) Optional[U]) Optional[U] {
+	if v, ok := o.Get(); ok {
+		return f(v)
+	}
+	return Empty[U]()
+}
+
+// Filter returns the optional if it wraps a value for which the function
+// returns true, otherwise an empty optional. The function is only called if
+// there is a value wrapped.
+func Filter[T any](o Optional[T], f func(value T) bool) Optional[T] {
+	if v, ok := o.Get(); ok && f(v) {
+		return o
+	}
+	return Empty[T]()
+}
+
+// Pair holds two values.
+type Pair[T, U any] struct {
+	First  T
+	Second U
+}
+
+// Triple holds three values.
+type Triple[T, U, V any] struct {
+	First  T
+	Second U
+	Third  V
+}
+
+// Zip returns an optional wrapping a pair of the values wrapped by both
+// optionals, or an empty optional if either optional is empty.
+func Zip[T, U any](a Optional[T], b Optional[U]) Optional[Pair[T, U]] {
+	av, aok := a.Get()
+	bv, bok := b.Get()
+	if aok && bok {
+		return Of(Pair[T, U]{First: av, Second: bv})
+	}
+	return Empty[Pair[T, U]]()
+}
+
+// Zip3 returns an optional wrapping a triple of the values wrapped by all three
+// optionals, or an empty optional if any optional is empty.
+func Zip3[T, U, V any](a Optional[T], b Optional[U], c Optional[V]) Optional[Triple[T, U, V]] {
+	av, aok := a.Get()
+	bv, bok := b.Get()
+	cv, cok := c.Get()
+	if aok && bok && cok {
+		return Of(Triple[T, U, V]{First: av, Second: bv, Third: cv})
+	}
+	return Empty[Triple[T, U, V]]()
+}
@@ -0,0 +1,131 @@
+package optional
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		Optional          Optional[int]
+		ExpectedCalled    bool
+		ExpectedValue     string
+		ExpectedIsPresent bool
+	}{
+		{Empty[int](), false, "", false},
+		{Of(0), true, "0", true},
+		{Of(1), true, "1", true},
+	}
+
+	for _, test := range tests {
+		called := false
+		value, ok := Map(test.Optional, func(i int) string {
+			called = true
+			return strconv.Itoa(i)
+		}).Get()
+
+		if called != test.ExpectedCalled || value != test.ExpectedValue || ok != test.ExpectedIsPresent {
+			t.Errorf("%#v Map got %#v, %#v, %#v, want %#v, %#v, %#v", test.Optional, called, value, ok, test.ExpectedCalled, test.ExpectedValue, test.ExpectedIsPresent)
+		}
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	tests := []struct {
+		Optional          Optional[string]
+		ExpectedCalled    bool
+		ExpectedValue     int
+		ExpectedIsPresent bool
+	}{
+		{Empty[string](), false, 0, false},
+		{Of("x"), true, 0, false},
+		{Of("1"), true, 1, true},
+	}
+
+	for _, test := range tests {
+		called := false
+		value, ok := FlatMap(test.Optional, func(s string) Optional[int] {
+			called = true
+			i, err := strconv.Atoi(s)
+			if err != nil {
+				return Empty[int]()
+			}
+			return Of(i)
+		}).Get()
+
+		if called != test.ExpectedCalled || value != test.ExpectedValue || ok != test.ExpectedIsPresent {
+			t.Errorf("%#v FlatMap got %#v, %#v, %#v, want %#v, %#v, %#v", test.Optional, called, value, ok, test.ExpectedCalled, test.ExpectedValue, test.ExpectedIsPresent)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		Optional          Optional[int]
+		ExpectedCalled    bool
+		ExpectedValue     int
+		ExpectedIsPresent bool
+	}{
+		{Empty[int](), false, 0, false},
+		{Of(1), true, 0, false},
+		{Of(2), true, 2, true},
+	}
+
+	for _, test := range tests {
+		called := false
+		value, ok := Filter(test.Optional, func(i int) bool {
+			called = true
+			return i%2 == 0
+		}).Get()
+
+		if called != test.ExpectedCalled || value != test.ExpectedValue || ok != test.ExpectedIsPresent {
+			t.Errorf("%#v Filter got %#v, %#v, %#v, want %#v, %#v, %#v", test.Optional, called, value, ok, test.ExpectedCalled, test.ExpectedValue, test.ExpectedIsPresent)
+		}
+	}
+}
+
+func TestZip(t *testing.T) {
+	tests := []struct {
+		A                 Optional[int]
+		B                 Optional[string]
+		ExpectedValue     Pair[int, string]
+		ExpectedIsPresent bool
+	}{
+		{Empty[int](), Empty[string](), Pair[int, string]{}, false},
+		{Of(1), Empty[string](), Pair[int, string]{}, false},
+		{Empty[int](), Of("a"), Pair[int, string]{}, false},
+		{Of(1), Of("a"), Pair[int, string]{1, "a"}, true},
+	}
+
+	for _, test := range tests {
+		value, ok := Zip(test.A, test.B).Get()
+
+		if value != test.ExpectedValue || ok != test.ExpectedIsPresent {
+			t.Errorf("%#v, %#v Zip got %#v, %#v, want %#v, %#v", test.A, test.B, value, ok, test.ExpectedValue, test.ExpectedIsPresent)
+		}
+	}
+}
+
+func TestZip3(t *testing.T) {
+	tests := []struct {
+		A                 Optional[int]
+		B                 Optional[string]
+		C                 Optional[bool]
+		ExpectedValue     Triple[int, string, bool]
+		ExpectedIsPresent bool
+	}{
+		{Empty[int](), Empty[string](), Empty[bool](), Triple[int, string, bool]{}, false},
+		{Of(1), Of("a"), Empty[bool](), Triple[int, string, bool]{}, false},
+		{Of(1), Empty[string](), Of(true), Triple[int, string, bool]{}, false},
+		{Empty[int](), Of("a"), Of(true), Triple[int, string, bool]{}, false},
+		{Of(1), Of("a"), Of(true), Triple[int, string, bool]{1, "a", true}, true},
+	}
+
+	for _, test := range tests {
+		value, ok := Zip3(test.A, test.B, test.C).Get()
+
+		if value != test.ExpectedValue || ok != test.ExpectedIsPresent {
+			t.Errorf("%#v, %#v, %#v Zip3 got %#v, %#v, want %#v, %#v", test.A, test.B, test.C, value, ok, test.ExpectedValue, test.ExpectedIsPresent)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package validate_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"4d63.com/optional"
+	"4d63.com/optional/validate"
+)
+
+func Example() {
+	type patch struct {
+		ID    optional.Field[int]    `json:"id" validate:"forbidden"`
+		Name  optional.Field[string] `json:"name" validate:"min=1,max=20"`
+		Email optional.Field[string] `json:"email" validate:"exclusive=contact"`
+		Phone optional.Field[string] `json:"phone" validate:"exclusive=contact"`
+	}
+
+	var p patch
+	_ = json.Unmarshal([]byte(`{"id":1,"name":"","email":"a@example.com","phone":"555"}`), &p)
+
+	err := validate.Struct(p)
+
+	var errs validate.Errors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			fmt.Println(e.Path, e.Message)
+		}
+	}
+
+	// Output:
+	// /id is forbidden
+	// /name must have a length of at least 1
+	// /phone cannot be present with /email
+}
@@ -0,0 +1,440 @@
+// Package validate checks structs of optionals against the rules in their
+// validate tags, and reports every failure with the JSON pointer of the field
+// that failed.
+//
+// Rules are separated by commas:
+//
+//	type Patch struct {
+//		Name  optional.Field[string] `json:"name" validate:"min=1,max=100"`
+//		Email optional.Field[string] `json:"email" validate:"exclusive=contact,regex=^[^@]+@[^@]+$"`
+//		Phone optional.Field[string] `json:"phone" validate:"exclusive=contact"`
+//		ID    optional.Field[int]    `json:"id" validate:"forbidden"`
+//	}
+//
+// The rules that check whether a field is present are:
+//
+//	required         the field must be present and have a value
+//	forbidden        the field must not be present
+//	required_with=F  the field must be present and have a value if field F of the same struct is present
+//	exclusive=G      at most one field in the group G of the same struct may be present
+//
+// An Optional is present if it wraps a value. A Field is present if it is
+// defined, so a field that is explicitly null fails forbidden, counts as
+// present for required_with and exclusive, and fails required and
+// required_with because it has no value. Pointers, slices, maps and
+// interfaces are present if they are not nil. Fields of other types are always
+// present, so the rules that check whether a field is present are an error on
+// them.
+//
+// The rules that check the value of a field only apply if the field has a
+// value, and are:
+//
+//	min=N    numbers must be at least N, and strings, slices and maps must have a length of at least N
+//	max=N    numbers must be at most N, and strings, slices and maps must have a length of at most N
+//	len=N    strings, slices and maps must have a length of N
+//	regex=R  strings must match the regular expression R
+//
+// The length of a string is its number of runes. The regex rule must be the
+// last rule in a tag, so that the expression can contain commas.
+//
+// Nested structs, pointers to structs, slices, arrays and maps of structs, and
+// optionals of structs that have a value, are checked too.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldError is a rule that a field failed.
+type FieldError struct {
+	// Path is the JSON pointer of the field, made of the names of the field
+	// and the fields it is nested in, using the names in their json tags.
+	Path string
+	// Rule is the name of the rule, such as required or min.
+	Rule string
+	// Message describes why the field failed the rule.
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Errors is the error returned by Struct when fields fail rules. It holds an
+// error for every rule that failed.
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Struct checks the struct, or the struct pointed to by v, against the rules
+// in its validate tags. If any fields fail a rule an Errors is returned. If a
+// validate tag is malformed, an error that is not an Errors is returned.
+func Struct(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("validate: cannot validate %T, want a struct or pointer to a struct", v)
+	}
+	c := checker{}
+	err := c.check(rv, "")
+	if err != nil {
+		return err
+	}
+	if len(c.errs) > 0 {
+		return c.errs
+	}
+	return nil
+}
+
+type checker struct {
+	errs Errors
+}
+
+func (c *checker) fail(path, rule, format string, args ...any) {
+	c.errs = append(c.errs, &FieldError{Path: path, Rule: rule, Message: fmt.Sprintf(format, args...)})
+}
+
+// check walks the value, checking any structs within it.
+func (c *checker) check(v reflect.Value, path string) error {
+	if isOptional, _, value := unwrap(v); isOptional {
+		if value.IsValid() {
+			return c.check(value, path)
+		}
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return c.check(v.Elem(), path)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			err := c.check(v.Index(i), path+"/"+strconv.Itoa(i))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			err := c.check(iter.Value(), path+"/"+escape(fmt.Sprint(iter.Key().Interface())))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		return c.checkStruct(v, path)
+	}
+	return nil
+}
+
+type field struct {
+	index   int
+	name    string
+	path    string
+	rules   []rule
+	present bool
+	value   reflect.Value // invalid if the field has no value
+}
+
+type rule struct {
+	name string
+	arg  string
+}
+
+func (c *checker) checkStruct(v reflect.Value, path string) error {
+	t := v.Type()
+	fields := []*field{}
+	byName := map[string]*field{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Tag.Get("json") == "" && f.Type.Kind() == reflect.Struct {
+			// Embedded structs are flattened into the struct in JSON, even
+			// if their type is unexported.
+			err := c.checkStruct(v.Field(i), path)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := jsonName(f)
+		if name == "" {
+			continue
+		}
+		rules, err := parseRules(f.Tag.Get("validate"))
+		if err != nil {
+			return fmt.Errorf("validate: %s.%s: %w", t, f.Name, err)
+		}
+		fv := v.Field(i)
+		isOptional, present, value := unwrap(fv)
+		if !isOptional {
+			present, value = true, fv
+			switch fv.Kind() {
+			case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+				present = !fv.IsNil()
+			default:
+				for _, r := range rules {
+					if isPresenceRule(r.name) {
+						return fmt.Errorf("validate: %s.%s: %s: cannot apply to %s, which is always present", t, f.Name, r.name, f.Type)
+					}
+				}
+			}
+		}
+		fd := &field{index: i, name: f.Name, path: path + "/" + escape(name), rules: rules, present: present, value: value}
+		fields = append(fields, fd)
+		byName[f.Name] = fd
+	}
+
+	groups := map[string][]*field{}
+	for _, f := range fields {
+		for _, r := range f.rules {
+			switch r.name {
+			case "required":
+				if !f.present {
+					c.fail(f.path, r.name, "is required")
+				} else if !f.value.IsValid() {
+					c.fail(f.path, r.name, "must not be null")
+				}
+			case "forbidden":
+				if f.present {
+					c.fail(f.path, r.name, "is forbidden")
+				}
+			case "required_with":
+				other, ok := byName[r.arg]
+				if !ok {
+					return fmt.Errorf("validate: %s.%s: required_with refers to unknown field %s", t, f.name, r.arg)
+				}
+				if other.present && (!f.present || !f.value.IsValid()) {
+					c.fail(f.path, r.name, "is required when %s is present", other.path)
+				}
+			case "exclusive":
+				if f.present {
+					groups[r.arg] = append(groups[r.arg], f)
+				}
+			default:
+				if !f.value.IsValid() {
+					continue
+				}
+				err := c.checkValue(f, r)
+				if err != nil {
+					return fmt.Errorf("validate: %s.%s: %w", t, f.name, err)
+				}
+			}
+		}
+	}
+	for _, f := range fields {
+		for _, r := range f.rules {
+			if r.name != "exclusive" || !f.present {
+				continue
+			}
+			group := groups[r.arg]
+			if len(group) > 1 && group[0] != f {
+				c.fail(f.path, r.name, "cannot be present with %s", group[0].path)
+			}
+		}
+	}
+
+	for _, f := range fields {
+		if !f.value.IsValid() {
+			continue
+		}
+		err := c.check(v.Field(f.index), f.path)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkValue checks the value of the field against a value rule, and returns
+// an error if the rule is malformed.
+func (c *checker) checkValue(f *field, r rule) error {
+	v := f.value
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch r.name {
+	case "min", "max":
+		n, err := strconv.ParseFloat(r.arg, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %w", r.name, err)
+		}
+		if l, ok := length(v); ok {
+			if r.name == "min" && float64(l) < n {
+				c.fail(f.path, r.name, "must have a length of at least %s", r.arg)
+			} else if r.name == "max" && float64(l) > n {
+				c.fail(f.path, r.name, "must have a length of at most %s", r.arg)
+			}
+			return nil
+		}
+		x, ok := number(v)
+		if !ok {
+			return fmt.Errorf("%s: cannot apply to %s", r.name, v.Type())
+		}
+		if r.name == "min" && x < n {
+			c.fail(f.path, r.name, "must be at least %s", r.arg)
+		} else if r.name == "max" && x > n {
+			c.fail(f.path, r.name, "must be at most %s", r.arg)
+		}
+	case "len":
+		n, err := strconv.Atoi(r.arg)
+		if err != nil {
+			return fmt.Errorf("%s: %w", r.name, err)
+		}
+		l, ok := length(v)
+		if !ok {
+			return fmt.Errorf("%s: cannot apply to %s", r.name, v.Type())
+		}
+		if l != n {
+			c.fail(f.path, r.name, "must have a length of %d", n)
+		}
+	case "regex":
+		re, err := regexp.Compile(r.arg)
+		if err != nil {
+			return fmt.Errorf("%s: %w", r.name, err)
+		}
+		if v.Kind() != reflect.String {
+			return fmt.Errorf("%s: cannot apply to %s", r.name, v.Type())
+		}
+		if !re.MatchString(v.String()) {
+			c.fail(f.path, r.name, "must match %s", r.arg)
+		}
+	}
+	return nil
+}
+
+// isPresenceRule returns true if the rule checks whether a field is present.
+func isPresenceRule(name string) bool {
+	switch name {
+	case "required", "forbidden", "required_with", "exclusive":
+		return true
+	}
+	return false
+}
+
+// parseRules parses the rules of a validate tag.
+func parseRules(tag string) ([]rule, error) {
+	var rules []rule
+	for tag != "" {
+		var s string
+		if strings.HasPrefix(tag, "regex=") {
+			s, tag = tag, ""
+		} else if i := strings.IndexByte(tag, ','); i >= 0 {
+			s, tag = tag[:i], tag[i+1:]
+		} else {
+			s, tag = tag, ""
+		}
+		name, arg, hasArg := strings.Cut(s, "=")
+		switch name {
+		case "required", "forbidden":
+			if hasArg {
+				return nil, fmt.Errorf("%s: does not take a value", name)
+			}
+		case "required_with", "exclusive", "min", "max", "len", "regex":
+			if arg == "" {
+				return nil, fmt.Errorf("%s: requires a value", name)
+			}
+		default:
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+		rules = append(rules, rule{name: name, arg: arg})
+	}
+	return rules, nil
+}
+
+// unwrap returns whether the value is an optional or a Field, whether it is
+// present, and the value it wraps, which is invalid if it does not wrap a
+// value.
+func unwrap(v reflect.Value) (isOptional, present bool, value reflect.Value) {
+	if !v.CanInterface() {
+		return false, false, reflect.Value{}
+	}
+	p, ok := v.Interface().(interface{ IsPresent() bool })
+	if !ok {
+		return false, false, reflect.Value{}
+	}
+	get := v.MethodByName("Get")
+	if !get.IsValid() || get.Type().NumIn() != 0 || get.Type().NumOut() != 2 || get.Type().Out(1).Kind() != reflect.Bool {
+		return false, false, reflect.Value{}
+	}
+	present = p.IsPresent()
+	if d, ok := v.Interface().(interface{ IsDefined() bool }); ok {
+		present = d.IsDefined()
+	}
+	out := get.Call(nil)
+	if out[1].Bool() {
+		value = out[0]
+	}
+	return true, present, value
+}
+
+// length returns the length of strings, slices, arrays and maps.
+func length(v reflect.Value) (int, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(v.String()), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), true
+	}
+	return 0, false
+}
+
+// number returns the value of numbers as a float64.
+func number(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// jsonName returns the name of the field in JSON, or the empty string if the
+// field is not marshaled to JSON.
+func jsonName(f reflect.StructField) string {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// escape escapes the reference token of a JSON pointer.
+func escape(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
@@ -0,0 +1,229 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"4d63.com/optional"
+)
+
+type address struct {
+	Street optional.Optional[string] `json:"street" validate:"required"`
+	Zip    optional.Optional[string] `json:"zip" validate:"len=5,regex=^[0-9]+$"`
+}
+
+type patch struct {
+	ID         optional.Field[int]        `json:"id" validate:"forbidden"`
+	Name       optional.Field[string]     `json:"name" validate:"required,min=1,max=5"`
+	Age        optional.Optional[int]     `json:"age" validate:"min=0,max=150"`
+	Email      optional.Optional[string]  `json:"email" validate:"exclusive=contact,regex=^[^@]+@[^@]+$"`
+	Phone      optional.Optional[string]  `json:"phone" validate:"exclusive=contact"`
+	Password   optional.Optional[string]  `json:"password"`
+	Confirm    optional.Optional[string]  `json:"confirm" validate:"required_with=Password"`
+	Tags       []string                   `json:"tags" validate:"max=2"`
+	Score      float64                    `json:"score,omitempty" validate:"max=1"`
+	Home       optional.Optional[address] `json:"home"`
+	Others     []address                  `json:"others"`
+	ByName     map[string]address         `json:"by/name"`
+	Ignored    optional.Optional[int]     `json:"-" validate:"required"`
+	unexported int
+}
+
+func TestStructValid(t *testing.T) {
+	tests := []patch{
+		{Name: optional.FieldOf("a")},
+		{Name: optional.FieldOf("abcde"), Age: optional.Of(0), Email: optional.Of("a@b")},
+		{Name: optional.FieldOf("a"), Phone: optional.Of("1"), Password: optional.Of("p"), Confirm: optional.Of("p")},
+		{Name: optional.FieldOf("a"), Home: optional.Of(address{Street: optional.Of("x"), Zip: optional.Of("12345")})},
+	}
+
+	for _, test := range tests {
+		err := Struct(test)
+		if err != nil {
+			t.Errorf("Struct(%+v) got %v, want nil", test, err)
+		}
+		err = Struct(&test)
+		if err != nil {
+			t.Errorf("Struct(%+v) got %v, want nil", &test, err)
+		}
+	}
+}
+
+func TestStructErrors(t *testing.T) {
+	p := patch{
+		ID:       optional.Null[int](),
+		Name:     optional.FieldOf("abcdef"),
+		Age:      optional.Of(-1),
+		Email:    optional.Of("invalid"),
+		Phone:    optional.Of("1"),
+		Password: optional.Of("p"),
+		Tags:     []string{"a", "b", "c"},
+		Score:    1.5,
+		Home:     optional.Of(address{Zip: optional.Of("1234a")}),
+		Others:   []address{{Street: optional.Of("x")}, {}},
+		ByName:   map[string]address{"a": {}},
+	}
+
+	err := Struct(p)
+
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Struct got %v, want Errors", err)
+	}
+	want := []FieldError{
+		{"/id", "forbidden", "is forbidden"},
+		{"/name", "max", "must have a length of at most 5"},
+		{"/age", "min", "must be at least 0"},
+		{"/email", "regex", "must match ^[^@]+@[^@]+$"},
+		{"/confirm", "required_with", "is required when /password is present"},
+		{"/tags", "max", "must have a length of at most 2"},
+		{"/score", "max", "must be at most 1"},
+		{"/phone", "exclusive", "cannot be present with /email"},
+		{"/home/street", "required", "is required"},
+		{"/home/zip", "regex", "must match ^[0-9]+$"},
+		{"/others/1/street", "required", "is required"},
+		{"/by~1name/a/street", "required", "is required"},
+	}
+	if len(errs) != len(want) {
+		t.Errorf("Struct got %d errors, want %d:\n%v", len(errs), len(want), err)
+	}
+	for i := 0; i < len(errs) && i < len(want); i++ {
+		if *errs[i] != want[i] {
+			t.Errorf("Struct error %d got %+v, want %+v", i, *errs[i], want[i])
+		}
+	}
+
+	wantMessage := "/id: is forbidden"
+	if got := errs[0].Error(); got != wantMessage {
+		t.Errorf("Error got %q, want %q", got, wantMessage)
+	}
+}
+
+func TestStructRequired(t *testing.T) {
+	err := Struct(patch{})
+
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Path != "/name" || errs[0].Rule != "required" {
+		t.Errorf("Struct got %v, want /name required", err)
+	}
+}
+
+func TestStructRequiredNull(t *testing.T) {
+	err := Struct(patch{Name: optional.Null[string]()})
+
+	var errs Errors
+	want := FieldError{"/name", "required", "must not be null"}
+	if !errors.As(err, &errs) || len(errs) != 1 || *errs[0] != want {
+		t.Errorf("Struct got %v, want %+v", err, want)
+	}
+}
+
+func TestStructNilable(t *testing.T) {
+	type nilable struct {
+		Name  *string        `json:"name" validate:"required"`
+		Tags  []string       `json:"tags" validate:"required_with=Name"`
+		Attrs map[string]int `json:"attrs" validate:"forbidden"`
+	}
+	name := "a"
+	tests := []struct {
+		Value    nilable
+		Expected []FieldError
+	}{
+		{nilable{}, []FieldError{{"/name", "required", "is required"}}},
+		{nilable{Name: &name}, []FieldError{{"/tags", "required_with", "is required when /name is present"}}},
+		{nilable{Name: &name, Tags: []string{}}, nil},
+		{nilable{Name: &name, Tags: []string{}, Attrs: map[string]int{}}, []FieldError{{"/attrs", "forbidden", "is forbidden"}}},
+	}
+
+	for _, test := range tests {
+		err := Struct(test.Value)
+
+		var errs Errors
+		if err != nil && !errors.As(err, &errs) {
+			t.Fatalf("Struct(%+v) got %v, want Errors", test.Value, err)
+		}
+		if len(errs) != len(test.Expected) {
+			t.Errorf("Struct(%+v) got %v, want %v", test.Value, err, test.Expected)
+			continue
+		}
+		for i := range errs {
+			if *errs[i] != test.Expected[i] {
+				t.Errorf("Struct(%+v) error %d got %+v, want %+v", test.Value, i, *errs[i], test.Expected[i])
+			}
+		}
+	}
+}
+
+type embedded struct {
+	Inner optional.Optional[int] `json:"inner" validate:"required"`
+}
+
+type outer struct {
+	embedded
+	Outer optional.Optional[int] `json:"outer"`
+}
+
+func TestStructEmbedded(t *testing.T) {
+	err := Struct(outer{})
+
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Path != "/inner" {
+		t.Errorf("Struct got %v, want /inner required", err)
+	}
+}
+
+func TestStructMalformed(t *testing.T) {
+	tests := []struct {
+		Value         any
+		ExpectedError string
+	}{
+		{
+			struct {
+				A optional.Optional[int] `validate:"unknown"`
+			}{},
+			`validate: struct { A optional.Optional[int] "validate:\"unknown\"" }.A: unknown rule "unknown"`,
+		},
+		{
+			struct {
+				A optional.Optional[int] `validate:"required=1"`
+			}{},
+			`validate: struct { A optional.Optional[int] "validate:\"required=1\"" }.A: required: does not take a value`,
+		},
+		{
+			struct {
+				A optional.Optional[int] `validate:"min="`
+			}{},
+			`validate: struct { A optional.Optional[int] "validate:\"min=\"" }.A: min: requires a value`,
+		},
+		{
+			struct {
+				A optional.Optional[int] `validate:"required_with=B"`
+			}{},
+			`validate: struct { A optional.Optional[int] "validate:\"required_with=B\"" }.A: required_with refers to unknown field B`,
+		},
+		{
+			struct {
+				A optional.Optional[bool] `validate:"min=1"`
+			}{A: optional.Of(true)},
+			`validate: struct { A optional.Optional[bool] "validate:\"min=1\"" }.A: min: cannot apply to bool`,
+		},
+		{
+			struct {
+				ID int `validate:"forbidden"`
+			}{},
+			`validate: struct { ID int "validate:\"forbidden\"" }.ID: forbidden: cannot apply to int, which is always present`,
+		},
+		{
+			1,
+			`validate: cannot validate int, want a struct or pointer to a struct`,
+		},
+	}
+
+	for _, test := range tests {
+		err := Struct(test.Value)
+		var errs Errors
+		if err == nil || errors.As(err, &errs) || err.Error() != test.ExpectedError {
+			t.Errorf("Struct got %v, want %v", err, test.ExpectedError)
+		}
+	}
+}